@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// Error codes sent by the agent so clients can restore the sentinel errors
+// the views check for.
+const (
+	agentErrNotSupported = "not_supported"
+	agentErrNotExist     = "not_exist"
+	agentErrPermission   = "permission"
+)
+
+// agentError is the JSON body of failed agent requests.
+type agentError struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// processesResponse is returned by the processes endpoint. The task summary
+// travels with the processes because it belongs to the same scan.
+type processesResponse struct {
+	Processes []ProcessInfo `json:"processes"`
+	Summary   TaskSummary   `json:"summary"`
+}
+
+// groupsResponse is returned by the groups endpoint.
+type groupsResponse struct {
+	Groups  []ProcessGroup `json:"groups"`
+	Summary TaskSummary    `json:"summary"`
+}
+
+// snapshotRequest selects the process data the snapshot endpoint returns
+// along with the stats. It follows the view of the client.
+type snapshotRequest struct {
+	Options ProcessOptions `json:"options"`
+	// Groups requests the process groups instead of the processes.
+	Groups bool `json:"groups,omitempty"`
+	// Ports requests the listening ports.
+	Ports bool `json:"ports,omitempty"`
+	// Threads and OpenFiles request the details of the process PID.
+	Threads   bool  `json:"threads,omitempty"`
+	OpenFiles bool  `json:"open_files,omitempty"`
+	PID       int32 `json:"pid,omitempty"`
+}
+
+// snapshotResponse is returned by the snapshot endpoint. It holds everything
+// a client shows on one refresh, so that a refresh takes a single request.
+type snapshotResponse struct {
+	HostInfo  *host.InfoStat         `json:"host"`
+	CpuUsage  *cpu.TimesStat         `json:"cpu"`
+	MemUsage  *mem.VirtualMemoryStat `json:"mem"`
+	SwapUsage *mem.SwapMemoryStat    `json:"swap"`
+	LoadAvg   *load.AvgStat          `json:"load"`
+	Pressure  *PressureStat          `json:"pressure"`
+	Sensors   *SensorStat            `json:"sensors"`
+	Power     *PowerStat             `json:"power"`
+
+	Processes []ProcessInfo   `json:"processes,omitempty"`
+	Groups    []ProcessGroup  `json:"groups,omitempty"`
+	Summary   TaskSummary     `json:"summary"`
+	Ports     []ListeningPort `json:"ports,omitempty"`
+	Threads   []ThreadInfo    `json:"threads,omitempty"`
+	OpenFiles []OpenFile      `json:"open_files,omitempty"`
+
+	// Errors holds the errors of the parts that failed, keyed by the name
+	// of their endpoint, or "details" for threads and open files.
+	Errors map[string]agentError `json:"errors,omitempty"`
+}
+
+// Agent serves a StatsFetcher and a ProcessManager over HTTP+JSON to
+// RemoteStatsFetcher and RemoteProcessManager clients. Every request must
+// carry the agent token as a bearer token.
+type Agent struct {
+	stats     StatsFetcher
+	processes ProcessManager
+	token     string
+
+	// mu serializes access to the process manager, which keeps the
+	// previous scan to compute CPU and I/O rates.
+	mu  sync.Mutex
+	mux *http.ServeMux
+}
+
+// NewAgent creates an Agent serving the given stats fetcher and process manager.
+func NewAgent(stats StatsFetcher, processes ProcessManager, token string) *Agent {
+	a := &Agent{
+		stats:     stats,
+		processes: processes,
+		token:     token,
+		mux:       http.NewServeMux(),
+	}
+
+	a.mux.HandleFunc("GET /v1/host", a.handle(func(r *http.Request) (any, error) { return a.stats.HostInfo() }))
+	a.mux.HandleFunc("GET /v1/cpu", a.handle(func(r *http.Request) (any, error) { return a.stats.CpuUsage() }))
+	a.mux.HandleFunc("GET /v1/mem", a.handle(func(r *http.Request) (any, error) { return a.stats.MemUsage() }))
+	a.mux.HandleFunc("GET /v1/swap", a.handle(func(r *http.Request) (any, error) { return a.stats.SwapUsage() }))
+	a.mux.HandleFunc("GET /v1/load", a.handle(func(r *http.Request) (any, error) { return a.stats.LoadAvg() }))
+	a.mux.HandleFunc("GET /v1/pressure", a.handle(func(r *http.Request) (any, error) { return a.stats.Pressure() }))
+	a.mux.HandleFunc("GET /v1/sensors", a.handle(func(r *http.Request) (any, error) { return a.stats.Sensors() }))
+	a.mux.HandleFunc("GET /v1/power", a.handle(func(r *http.Request) (any, error) { return a.stats.Power() }))
+
+	a.mux.HandleFunc("POST /v1/processes", a.handle(a.getProcesses))
+	a.mux.HandleFunc("POST /v1/groups", a.handle(a.getGroups))
+	a.mux.HandleFunc("GET /v1/processes/{pid}/threads", a.handle(func(r *http.Request) (any, error) {
+		pid, err := pathPID(r)
+		if err != nil {
+			return nil, err
+		}
+		return a.locked(func() (any, error) { return a.processes.GetThreads(pid) })
+	}))
+	a.mux.HandleFunc("GET /v1/processes/{pid}/files", a.handle(func(r *http.Request) (any, error) {
+		pid, err := pathPID(r)
+		if err != nil {
+			return nil, err
+		}
+		return a.processes.GetOpenFiles(pid)
+	}))
+	a.mux.HandleFunc("GET /v1/ports", a.handle(func(r *http.Request) (any, error) { return a.processes.GetListeningPorts() }))
+	a.mux.HandleFunc("POST /v1/snapshot", a.handle(a.getSnapshot))
+
+	return a
+}
+
+// ServeHTTP checks the bearer token and dispatches the request.
+func (a *Agent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, agentError{Error: "invalid or missing token"})
+		return
+	}
+	a.mux.ServeHTTP(w, r)
+}
+
+func (a *Agent) getProcesses(r *http.Request) (any, error) {
+	var opts ProcessOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		return nil, errBadRequest
+	}
+	return a.locked(func() (any, error) {
+		processes, err := a.processes.GetProcesses(opts)
+		if err != nil {
+			return nil, err
+		}
+		return processesResponse{Processes: processes, Summary: a.processes.TaskSummary()}, nil
+	})
+}
+
+func (a *Agent) getGroups(r *http.Request) (any, error) {
+	var opts ProcessOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		return nil, errBadRequest
+	}
+	return a.locked(func() (any, error) {
+		groups, err := a.processes.GetGroups(opts)
+		if err != nil {
+			return nil, err
+		}
+		return groupsResponse{Groups: groups, Summary: a.processes.TaskSummary()}, nil
+	})
+}
+
+func (a *Agent) getSnapshot(r *http.Request) (any, error) {
+	var req snapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errBadRequest
+	}
+	return a.locked(func() (any, error) { return a.snapshot(req), nil })
+}
+
+// snapshot collects the stats and the process data selected by req. Failed
+// parts are reported in the response instead of failing the request.
+func (a *Agent) snapshot(req snapshotRequest) snapshotResponse {
+	var resp snapshotResponse
+	record := func(part string, err error) {
+		if err == nil {
+			return
+		}
+		if resp.Errors == nil {
+			resp.Errors = make(map[string]agentError)
+		}
+		_, resp.Errors[part] = agentErrorFor(err)
+	}
+
+	var err error
+	resp.HostInfo, err = a.stats.HostInfo()
+	record("host", err)
+	resp.CpuUsage, err = a.stats.CpuUsage()
+	record("cpu", err)
+	resp.MemUsage, err = a.stats.MemUsage()
+	record("mem", err)
+	resp.SwapUsage, err = a.stats.SwapUsage()
+	record("swap", err)
+	resp.LoadAvg, err = a.stats.LoadAvg()
+	record("load", err)
+	resp.Pressure, err = a.stats.Pressure()
+	record("pressure", err)
+	resp.Sensors, err = a.stats.Sensors()
+	record("sensors", err)
+	resp.Power, err = a.stats.Power()
+	record("power", err)
+
+	if req.Groups {
+		resp.Groups, err = a.processes.GetGroups(req.Options)
+	} else {
+		resp.Processes, err = a.processes.GetProcesses(req.Options)
+	}
+	record("processes", err)
+	resp.Summary = a.processes.TaskSummary()
+
+	switch {
+	case req.Ports:
+		resp.Ports, err = a.processes.GetListeningPorts()
+		record("ports", err)
+	case req.Threads:
+		resp.Threads, err = a.processes.GetThreads(req.PID)
+		record("details", err)
+	case req.OpenFiles:
+		resp.OpenFiles, err = a.processes.GetOpenFiles(req.PID)
+		record("details", err)
+	}
+	return resp
+}
+
+// locked runs fn while holding the process manager lock.
+func (a *Agent) locked(fn func() (any, error)) (any, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return fn()
+}
+
+// errBadRequest is returned for malformed request bodies and paths.
+var errBadRequest = errors.New("bad request")
+
+// handle adapts fn to an http.HandlerFunc, encoding its result or error as JSON.
+func (a *Agent) handle(fn func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := fn(r)
+		if err == nil {
+			writeJSON(w, http.StatusOK, result)
+			return
+		}
+
+		status, agentErr := agentErrorFor(err)
+		if status == http.StatusInternalServerError {
+			slog.Error("Agent request failed", "path", r.URL.Path, "error", err)
+		}
+		writeJSON(w, status, agentErr)
+	}
+}
+
+// agentErrorFor returns the HTTP status and the JSON body reporting err.
+func agentErrorFor(err error) (int, agentError) {
+	status, code := http.StatusInternalServerError, ""
+	switch {
+	case errors.Is(err, errBadRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrNotSupported):
+		status, code = http.StatusNotImplemented, agentErrNotSupported
+	case errors.Is(err, fs.ErrNotExist):
+		status, code = http.StatusNotFound, agentErrNotExist
+	case errors.Is(err, fs.ErrPermission):
+		status, code = http.StatusForbidden, agentErrPermission
+	}
+	return status, agentError{Error: err.Error(), Code: code}
+}
+
+// pathPID parses the {pid} path segment.
+func pathPID(r *http.Request) (int32, error) {
+	pid, err := strconv.ParseInt(r.PathValue("pid"), 10, 32)
+	if err != nil {
+		return 0, errBadRequest
+	}
+	return int32(pid), nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(prefix) || auth[:len(prefix)] != prefix {
+		return "", false
+	}
+	return auth[len(prefix):], true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to encode agent response", "error", err)
+	}
+}
@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestAgent serves an agent over fakes with the token "t".
+func newTestAgent(t *testing.T) (*httptest.Server, *fakeProcesses) {
+	t.Helper()
+
+	processes := newFakeProcesses(10)
+	server := httptest.NewServer(NewAgent(fakeStats{}, processes, "t"))
+	t.Cleanup(server.Close)
+	return server, processes
+}
+
+func TestAgentRoundTrip(t *testing.T) {
+	server, fake := newTestAgent(t)
+	client := NewRemoteClient(server.URL, "t")
+	stats := NewRemoteStatsFetcher(client)
+	processes := NewRemoteProcessManager(client)
+
+	info, err := stats.HostInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Hostname != "fake" {
+		t.Errorf("Hostname = %q, want fake", info.Hostname)
+	}
+	cpuUsage, err := stats.CpuUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpuUsage.User != 30 || cpuUsage.Idle != 50 {
+		t.Errorf("CpuUsage = %+v, want user 30 and idle 50", cpuUsage)
+	}
+
+	opts := ProcessOptions{SortBy: SortByPID, Ascending: true, Limit: 3, Include: []int32{9}}
+	got, err := processes.GetProcesses(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.opts.SortBy != SortByPID || !fake.opts.Ascending || fake.opts.Limit != 3 {
+		t.Errorf("agent received options %+v, want %+v", fake.opts, opts)
+	}
+	var pids []int32
+	for _, p := range got {
+		pids = append(pids, p.PID)
+	}
+	if want := []int32{1, 2, 3, 9}; len(pids) != len(want) || pids[0] != 1 || pids[3] != 9 {
+		t.Errorf("GetProcesses returned PIDs %v, want %v", pids, want)
+	}
+	if summary := processes.TaskSummary(); summary.Total != 10 || summary.Sleeping != 10 {
+		t.Errorf("TaskSummary = %+v, want 10 sleeping processes", summary)
+	}
+}
+
+func TestAgentRejectsToken(t *testing.T) {
+	server, _ := newTestAgent(t)
+
+	for _, auth := range []string{"", "Bearer", "Bearer wrong", "Basic t"} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/host", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: status %d, want %d", auth, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+
+	stats := NewRemoteStatsFetcher(NewRemoteClient(server.URL, "wrong"))
+	if _, err := stats.HostInfo(); err == nil {
+		t.Error("HostInfo with a wrong token succeeded")
+	}
+}
+
+func TestAgentErrors(t *testing.T) {
+	server, _ := newTestAgent(t)
+	client := NewRemoteClient(server.URL, "t")
+	stats := NewRemoteStatsFetcher(client)
+	processes := NewRemoteProcessManager(client)
+
+	if _, err := processes.GetThreads(1); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("GetThreads error = %v, want ErrNotSupported", err)
+	}
+	if _, err := processes.GetOpenFiles(1); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("GetOpenFiles error = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := stats.Pressure(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Pressure error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestAgentSnapshot(t *testing.T) {
+	server, _ := newTestAgent(t)
+	stats := NewRemoteStatsFetcher(NewRemoteClient(server.URL, "t"))
+
+	resp, err := stats.snapshot(snapshotRequest{Options: ProcessOptions{SortBy: SortByCPU, Limit: 5}, OpenFiles: true, PID: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.HostInfo.Hostname != "fake" || resp.Summary.Total != 10 {
+		t.Errorf("snapshot host %q with %d tasks, want fake with 10", resp.HostInfo.Hostname, resp.Summary.Total)
+	}
+	if len(resp.Processes) != 5 || resp.Processes[0].PID != 10 {
+		t.Errorf("snapshot returned %d processes, want the 5 busiest from PID 10", len(resp.Processes))
+	}
+	if err := resp.err("details"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("details error = %v, want fs.ErrNotExist", err)
+	}
+	if err := resp.err("pressure"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("pressure error = %v, want fs.ErrNotExist", err)
+	}
+	if err := resp.err("cpu"); err != nil {
+		t.Errorf("cpu error = %v, want none", err)
+	}
+}
+
+func TestModelFetchesSnapshotInBackground(t *testing.T) {
+	server, _ := newTestAgent(t)
+	client := NewRemoteClient(server.URL, "t")
+	m := NewModel(*DefaultConfig(), NewRemoteStatsFetcher(client), NewRemoteProcessManager(client))
+
+	updated, cmd := m.Update(TickMsg{Time: time.Now()})
+	if updated.(Model).hasLoaded {
+		t.Fatal("tick loaded the snapshot inside Update")
+	}
+	if cmd == nil {
+		t.Fatal("tick returned no command")
+	}
+
+	result := cmd()
+	msg, ok := result.(snapshotMsg)
+	if !ok {
+		t.Fatalf("command returned %T, want snapshotMsg", result)
+	}
+	updated, cmd = updated.Update(msg)
+	m = updated.(Model)
+	if !m.hasLoaded || m.HostInfo.Hostname != "fake" || len(m.processes) != 10 {
+		t.Errorf("after the snapshot: loaded %t, host %q, %d processes", m.hasLoaded, m.HostInfo.Hostname, len(m.processes))
+	}
+	if cmd == nil {
+		t.Error("snapshot did not schedule the next tick")
+	}
+}
+
+func TestModelLoadsActionsInBackground(t *testing.T) {
+	var requests atomic.Int32
+	agent := NewAgent(fakeStats{}, newFakeProcesses(10), "t")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		agent.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client := NewRemoteClient(server.URL, "t")
+
+	var m tea.Model = NewModel(*DefaultConfig(), NewRemoteStatsFetcher(client), NewRemoteProcessManager(client))
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, cmd := m.Update(TickMsg{Time: time.Now()})
+	m, _ = m.Update(cmd())
+
+	// press sends a key and runs the command it returns, checking that
+	// the agent is only queried by the command.
+	press := func(m tea.Model, keys string) tea.Model {
+		t.Helper()
+		var cmd tea.Cmd
+		before := requests.Load()
+		for _, r := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == '\n' {
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+			m, cmd = m.Update(msg)
+		}
+		if n := requests.Load() - before; n != 0 {
+			t.Fatalf("%q made %d requests inside Update", keys, n)
+		}
+		if cmd == nil {
+			t.Fatalf("%q returned no command", keys)
+		}
+		m, next := m.Update(cmd())
+		if next != nil {
+			t.Errorf("%q scheduled a tick", keys)
+		}
+		return m
+	}
+
+	m = press(m, "s")
+	if got := m.(Model); got.processOptions.SortBy != SortByMemory || got.processes[0].PID != 1 {
+		t.Errorf("after sorting: sort %s with PID %d on top, want memory with PID 1", got.processOptions.SortBy, got.processes[0].PID)
+	}
+
+	m = press(m, "*")
+	if got := m.(Model); len(got.processOptions.Pins) != 1 || !slices.Contains(got.processTable.SelectedRow(), "• proc-1") {
+		t.Errorf("after pinning: pins %v, selected row %v", got.processOptions.Pins, got.processTable.SelectedRow())
+	}
+
+	m = press(m, "#7\n")
+	if got := m.(Model); got.promptErr != "" || got.processTable.SelectedRow()[0] != "7" {
+		t.Errorf("after jumping to PID 7: error %q, selected row %v", got.promptErr, got.processTable.SelectedRow())
+	}
+	m = press(m, "#99\n")
+	if got := m.(Model); got.promptErr != "no process with PID 99" || got.processTable.SelectedRow()[0] != "7" {
+		t.Errorf("after jumping to PID 99: error %q, selected row %v", got.promptErr, got.processTable.SelectedRow())
+	}
+
+	m = press(m, "t")
+	if got := m.(Model); got.mode != modeThreads || got.detailPID != 7 || !errors.Is(got.detailErr, ErrNotSupported) {
+		t.Errorf("after opening threads: mode %v of PID %d, error %v", got.mode, got.detailPID, got.detailErr)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = press(m, "p")
+	if got := m.(Model); got.mode != modePorts || len(got.ports) != 1 || got.ports[0].LocalPort != 22 {
+		t.Errorf("after opening ports: mode %v with ports %+v", got.mode, got.ports)
+	}
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// containerIDPattern matches the 64 character hex IDs used by container runtimes.
+var containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// podUIDPattern matches the pod UID in kubepods cgroup paths, in both the
+// cgroupfs ("pod1234-...") and systemd ("pod1234_...") spellings.
+var podUIDPattern = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+
+// parseCgroupFile returns the cgroup v2 path from the contents of /proc/PID/cgroup.
+// On hybrid hierarchies without a unified entry it falls back to the systemd hierarchy.
+func parseCgroupFile(data []byte) string {
+	var fallback string
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		parts := strings.SplitN(string(line), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			return parts[2]
+		}
+		if parts[1] == "name=systemd" {
+			fallback = parts[2]
+		}
+	}
+	return fallback
+}
+
+// containerName returns a short name for the container a cgroup path belongs to,
+// such as "docker:3f4e5a6b7c8d" or "k8s:1a2b3c4d/3f4e5a6b7c8d", or "" for host processes.
+func containerName(cgroupPath string) string {
+	id := containerIDPattern.FindString(cgroupPath)
+	pod := podUIDPattern.FindStringSubmatch(cgroupPath)
+
+	switch {
+	case pod != nil && id != "":
+		return "k8s:" + pod[1][:8] + "/" + id[:12]
+	case pod != nil:
+		return "k8s:" + pod[1][:8]
+	case id == "":
+		return ""
+	}
+
+	switch {
+	case strings.Contains(cgroupPath, "libpod"):
+		return "podman:" + id[:12]
+	case strings.Contains(cgroupPath, "crio"):
+		return "cri-o:" + id[:12]
+	case strings.Contains(cgroupPath, "containerd"):
+		return "containerd:" + id[:12]
+	case strings.Contains(cgroupPath, "docker"):
+		return "docker:" + id[:12]
+	}
+	return "container:" + id[:12]
+}
+
+// systemdUnitSuffixes are the unit types systemd creates cgroups for.
+var systemdUnitSuffixes = []string{".service", ".scope", ".slice"}
+
+// systemdUnit returns the innermost systemd unit of a cgroup path and the
+// cgroup path of that unit, e.g. "foo.service" and "/system.slice/foo.service"
+// for "/system.slice/foo.service/worker". Processes outside any unit belong
+// to the root slice "-.slice".
+func systemdUnit(cgroupPath string) (string, string) {
+	parts := strings.Split(strings.Trim(cgroupPath, "/"), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		for _, suffix := range systemdUnitSuffixes {
+			if strings.HasSuffix(parts[i], suffix) {
+				return parts[i], "/" + strings.Join(parts[:i+1], "/")
+			}
+		}
+	}
+	return "-.slice", "/"
+}
+
+// CgroupLimits holds the limits and usage of a cgroup v2 group.
+// The Has fields are false when the controller files are missing, such as
+// for the root cgroup or when the controller is not enabled.
+type CgroupLimits struct {
+	HasMemory     bool
+	MemoryCurrent uint64
+	MemoryMax     uint64 // 0 when unlimited
+	OOMKills      uint64
+
+	HasCPU        bool
+	CPUQuota      float64 // in CPUs, 0 when unlimited
+	NrThrottled   uint64
+	ThrottledTime time.Duration
+
+	HasPids     bool
+	PidsCurrent uint64
+	PidsMax     uint64 // 0 when unlimited
+}
+
+// readCgroupLimits reads the limits of the cgroup at path below the cgroup2 mount at root.
+func readCgroupLimits(root, path string) *CgroupLimits {
+	dir := filepath.Join(root, path)
+	limits := &CgroupLimits{}
+
+	if current, ok := readCgroupValue(dir, "memory.current"); ok {
+		limits.HasMemory = true
+		limits.MemoryCurrent = current
+		limits.MemoryMax, _ = readCgroupValue(dir, "memory.max")
+		if events, err := os.ReadFile(filepath.Join(dir, "memory.events")); err == nil {
+			if value, ok := statusField(events, "oom_kill "); ok {
+				limits.OOMKills, _ = fieldUint(value, 0)
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "cpu.max")); err == nil {
+		limits.HasCPU = true
+		fields := strings.Fields(string(data))
+		if len(fields) == 2 && fields[0] != "max" {
+			quota, _ := strconv.ParseFloat(fields[0], 64)
+			period, _ := strconv.ParseFloat(fields[1], 64)
+			if period > 0 {
+				limits.CPUQuota = quota / period
+			}
+		}
+		if stat, err := os.ReadFile(filepath.Join(dir, "cpu.stat")); err == nil {
+			if value, ok := statusField(stat, "nr_throttled "); ok {
+				limits.NrThrottled, _ = fieldUint(value, 0)
+			}
+			if value, ok := statusField(stat, "throttled_usec "); ok {
+				usec, _ := fieldUint(value, 0)
+				limits.ThrottledTime = time.Duration(usec) * time.Microsecond
+			}
+		}
+	}
+
+	if current, ok := readCgroupValue(dir, "pids.current"); ok {
+		limits.HasPids = true
+		limits.PidsCurrent = current
+		limits.PidsMax, _ = readCgroupValue(dir, "pids.max")
+	}
+
+	return limits
+}
+
+// readCgroupValue reads a single value cgroup file. "max" is returned as 0.
+func readCgroupValue(dir, name string) (uint64, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, true
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	return v, err == nil
+}
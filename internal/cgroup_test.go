@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestContainerNameFixtures(t *testing.T) {
+	tests := []struct {
+		pid  string
+		want string
+	}{
+		{"1", ""},
+		{"42", "docker:3f4e5a6b7c8d"},
+		{"77", "docker:3f4e5a6b7c8d"},
+		{"100", ""},
+		{"200", ""},
+		{"300", "k8s:0f1e2d3c/aabbccddeeff"},
+		{"400", "podman:1234567890ab"},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join("testdata", "procfs", tt.pid, "cgroup"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := parseCgroupFile(data)
+		if got := containerName(path); got != tt.want {
+			t.Errorf("containerName(%q) of PID %s = %q, want %q", path, tt.pid, got, tt.want)
+		}
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	const id = "aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899"
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/kubepods/burstable/pod0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b/" + id, "k8s:0f1e2d3c/aabbccddeeff"},
+		{"/kubepods.slice/kubepods-pod0f1e2d3c_4b5a_6978_8a9b_0c1d2e3f4a5b.slice", "k8s:0f1e2d3c"},
+		{"/machine.slice/crio-" + id + ".scope", "cri-o:aabbccddeeff"},
+		{"/system.slice/containerd.service/" + id, "containerd:aabbccddeeff"},
+		{"/docker/" + id, "docker:aabbccddeeff"},
+		{"/lxc.payload/" + id, "container:aabbccddeeff"},
+		{"/system.slice/sshd.service", ""},
+	}
+	for _, tt := range tests {
+		if got := containerName(tt.path); got != tt.want {
+			t.Errorf("containerName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestReadCgroupLimits(t *testing.T) {
+	root := filepath.Join("testdata", "sysfs", "fs", "cgroup")
+	tests := []struct {
+		name  string
+		path  string
+		want  CgroupLimits
+		cells []string
+	}{
+		{
+			name: "limited",
+			path: "/system.slice/docker-3f4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f.scope",
+			want: CgroupLimits{
+				HasMemory: true, MemoryCurrent: 488636416, MemoryMax: 536870912, OOMKills: 2,
+				HasCPU: true, CPUQuota: 1.5, NrThrottled: 1234, ThrottledTime: 45678901 * time.Microsecond,
+				HasPids: true, PidsCurrent: 12, PidsMax: 100,
+			},
+			cells: []string{"466.00MB/512.00MB", "91%", "1.50", "45.7s", "12/100", "2"},
+		},
+		{
+			name: "unlimited",
+			path: "/user.slice/user-1000.slice/session-2.scope",
+			want: CgroupLimits{
+				HasMemory: true, MemoryCurrent: 104857600,
+				HasCPU:  true,
+				HasPids: true, PidsCurrent: 3,
+			},
+			cells: []string{"100.00MB/max", "-", "max", "0s", "3/max", "0"},
+		},
+		{
+			name:  "no controllers",
+			path:  "/user.slice",
+			want:  CgroupLimits{},
+			cells: []string{"-", "-", "-", "-", "-", "-"},
+		},
+		{
+			name:  "missing cgroup",
+			path:  "/system.slice/gone.service",
+			want:  CgroupLimits{},
+			cells: []string{"-", "-", "-", "-", "-", "-"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := readCgroupLimits(root, tt.path)
+			if *limits != tt.want {
+				t.Errorf("readCgroupLimits = %+v, want %+v", *limits, tt.want)
+			}
+			if cells := cgroupLimitCells(limits); !slices.Equal(cells, tt.cells) {
+				t.Errorf("cgroupLimitCells = %q, want %q", cells, tt.cells)
+			}
+		})
+	}
+
+	if cells := cgroupLimitCells(nil); !slices.Equal(cells, []string{"-", "-", "-", "-", "-", "-"}) {
+		t.Errorf("cgroupLimitCells(nil) = %q, want only dashes", cells)
+	}
+}
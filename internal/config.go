@@ -1,6 +1,10 @@
 package internal
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -10,6 +14,20 @@ type ColorConfig struct {
 	TableSelectionBackground lipgloss.Color
 	ProgressBarFilled        lipgloss.Color
 	ProgressBarEmpty         lipgloss.Color
+	StateRunning             lipgloss.Color
+	StateUninterruptible     lipgloss.Color
+	StateStopped             lipgloss.Color
+	StateZombie              lipgloss.Color
+	Warning                  lipgloss.Color
+	Critical                 lipgloss.Color
+	// CPU time categories in the stacked CPU usage bar.
+	CPUUser   lipgloss.Color
+	CPUNice   lipgloss.Color
+	CPUSystem lipgloss.Color
+	CPUIowait lipgloss.Color
+	CPUIrq    lipgloss.Color
+	CPUSteal  lipgloss.Color
+	CPUGuest  lipgloss.Color
 }
 
 type Config struct {
@@ -17,6 +35,11 @@ type Config struct {
 	ProcessLimit       int
 	Colors             ColorConfig
 	ProcessTableHeight int
+	Keys               KeyMap
+	// Pins keep matching processes at the top of the process list,
+	// beyond ProcessLimit. Highlights style the rows of matching processes.
+	Pins       []ProcessMatcher
+	Highlights []HighlightRule
 }
 
 func DefaultConfig() *Config {
@@ -27,13 +50,83 @@ func DefaultConfig() *Config {
 			TableSelectionBackground: lipgloss.Color("62"),
 			ProgressBarFilled:        lipgloss.Color("#aad700"),
 			ProgressBarEmpty:         lipgloss.Color("#e7e3db"),
+			StateRunning:             lipgloss.Color("#aad700"),
+			StateUninterruptible:     lipgloss.Color("#ff8700"),
+			StateStopped:             lipgloss.Color("#ffd75f"),
+			StateZombie:              lipgloss.Color("#ff5f5f"),
+			Warning:                  lipgloss.Color("#ffd75f"),
+			Critical:                 lipgloss.Color("#ff5f5f"),
+			CPUUser:                  lipgloss.Color("#aad700"),
+			CPUNice:                  lipgloss.Color("#5fafff"),
+			CPUSystem:                lipgloss.Color("#ff5f5f"),
+			CPUIowait:                lipgloss.Color("#ffd75f"),
+			CPUIrq:                   lipgloss.Color("#af87ff"),
+			CPUSteal:                 lipgloss.Color("#ff8700"),
+			CPUGuest:                 lipgloss.Color("#00d7d7"),
 		},
 		ProcessTableHeight: 25,
+		Keys:               DefaultKeyMap(),
 	}
 }
 
+// fileConfig is the layout of the JSON configuration file.
+type fileConfig struct {
+	// Keys maps binding names such as "quit" or "open_files" to their keys.
+	Keys       map[string][]string `json:"keys"`
+	Pins       []ProcessMatcher    `json:"pins"`
+	Highlights []HighlightRule     `json:"highlights"`
+}
+
+// LoadConfig returns the default configuration with the settings of the
+// JSON configuration file at path applied.
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file fileConfig
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for name, keys := range file.Keys {
+		if err := config.Keys.Remap(name, keys); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	for i, pin := range file.Pins {
+		if err := pin.validate(); err != nil {
+			return nil, fmt.Errorf("parsing %s: pin %d: %w", path, i+1, err)
+		}
+	}
+	for i, rule := range file.Highlights {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("parsing %s: highlight %d: %w", path, i+1, err)
+		}
+	}
+	config.Pins = file.Pins
+	config.Highlights = file.Highlights
+	return config, nil
+}
+
+// DefaultConfigPath returns the path of the configuration file read when
+// no -config flag is given, such as ~/.config/mintop/config.json on Linux.
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mintop", "config.json")
+}
+
+// WithRefreshInterval sets the refresh interval, clamped to the range it can
+// be changed within at runtime. Zero and negative intervals become the shortest.
 func (c *Config) WithRefreshInterval(d time.Duration) Config {
-	c.RefreshInterval = d
+	c.RefreshInterval = min(max(d, refreshIntervals[0]), refreshIntervals[len(refreshIntervals)-1])
 	return *c
 }
 
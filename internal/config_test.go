@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWithRefreshIntervalClamps(t *testing.T) {
+	tests := []struct {
+		in, want time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{-time.Second, 100 * time.Millisecond},
+		{10 * time.Millisecond, 100 * time.Millisecond},
+		{1500 * time.Millisecond, 1500 * time.Millisecond},
+		{time.Hour, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultConfig().WithRefreshInterval(tt.in).RefreshInterval; got != tt.want {
+			t.Errorf("WithRefreshInterval(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshIntervalRestartsTicks(t *testing.T) {
+	m := newMouseTestModel(t)
+	loaded := m.lastUpdate
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = updated.(Model)
+	if m.config.RefreshInterval != 2*time.Second || cmd == nil {
+		t.Fatalf("interval %s with command %t after +, want 2s and a new tick", m.config.RefreshInterval, cmd != nil)
+	}
+
+	// The tick still pending from the old loop is dropped.
+	updated, cmd = m.Update(TickMsg{Time: loaded.Add(time.Second)})
+	m = updated.(Model)
+	if cmd != nil || !m.lastUpdate.Equal(loaded) {
+		t.Errorf("tick of the old loop refreshed at %s and scheduled %t, want it dropped", m.lastUpdate, cmd != nil)
+	}
+
+	updated, cmd = m.Update(TickMsg{Time: loaded.Add(2 * time.Second), gen: m.tickGen})
+	m = updated.(Model)
+	if cmd == nil || !m.lastUpdate.Equal(loaded.Add(2*time.Second)) {
+		t.Errorf("tick of the new loop refreshed at %s and scheduled %t, want a refresh and the next tick", m.lastUpdate, cmd != nil)
+	}
+
+	// A snapshot fetched for the old loop is shown without scheduling a tick.
+	updated, cmd = m.Update(snapshotMsg{frame: m.frame(), gen: m.tickGen - 1})
+	if cmd != nil {
+		t.Error("snapshot of the old loop scheduled a tick")
+	}
+	if _, cmd = updated.Update(snapshotMsg{frame: m.frame(), gen: m.tickGen}); cmd == nil {
+		t.Error("snapshot of the current loop did not schedule the next tick")
+	}
+}
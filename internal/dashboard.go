@@ -0,0 +1,326 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hostSummary holds the overview of a single agent shown in the dashboard.
+type hostSummary struct {
+	Addr     string
+	Hostname string
+	CPU      float64 // percent busy
+	Memory   float64 // percent used
+	Load     [3]float64
+	Tasks    TaskSummary
+	// Top is the process using the most CPU, nil when the host has none.
+	Top *ProcessInfo
+	// Err is set when the agent could not be reached.
+	Err error
+	// Loaded is false until the agent answered for the first time.
+	Loaded bool
+}
+
+// dashboardHost is an agent connection of the dashboard.
+type dashboardHost struct {
+	addr      string
+	stats     RemoteStatsFetcher
+	processes ProcessManager
+}
+
+// Dashboard is a Bubble Tea model showing an overview of several hosts
+// running mintop agents. Selecting a host drills down into the usual
+// single host Model, and esc from its process list returns to the overview.
+type Dashboard struct {
+	config Config
+	width  int
+	height int
+
+	hosts     []dashboardHost
+	summaries []hostSummary
+	// fetching is set for the hosts whose summary is being fetched, so a
+	// slow agent is not queried again before it answered.
+	fetching  []bool
+	table     Table
+	hasLoaded bool
+	// tickGen is the generation of the running tick loop, which the detail
+	// view takes over while drilled down.
+	tickGen int
+
+	// detail is the single host view of the drilled down host, nil in the overview.
+	detail *Model
+}
+
+// NewDashboard creates a Dashboard over the agents at addrs, all sharing the same token.
+func NewDashboard(config Config, addrs []string, token string) Dashboard {
+	tableStyle := DefaultTableStyles()
+	tableStyle.Selected = lipgloss.NewStyle().Background(config.Colors.TableSelectionBackground)
+
+	hosts := make([]dashboardHost, 0, len(addrs))
+	summaries := make([]hostSummary, 0, len(addrs))
+	fetching := make([]bool, 0, len(addrs))
+	for _, addr := range addrs {
+		client := NewRemoteClient(addr, token)
+		hosts = append(hosts, dashboardHost{
+			addr:      addr,
+			stats:     NewRemoteStatsFetcher(client),
+			processes: NewRemoteProcessManager(client),
+		})
+		summaries = append(summaries, hostSummary{Addr: addr})
+		// Init fetches every host.
+		fetching = append(fetching, true)
+	}
+
+	return Dashboard{
+		config:    config,
+		hosts:     hosts,
+		summaries: summaries,
+		fetching:  fetching,
+		table:     NewTable(dashboardColumns(config), config.ProcessTableHeight, tableStyle),
+	}
+}
+
+// dashboardColumns defines the table header of the host overview.
+func dashboardColumns(config Config) []Column {
+	return []Column{
+		{Title: "Host", Width: 24},
+		{Title: "CPU%", Width: 8, Style: thresholdStyle(config.Colors, 75, 90)},
+		{Title: "MEM%", Width: 8, Style: thresholdStyle(config.Colors, 75, 90)},
+		{Title: "Load 1/5/15", Width: 18},
+		{Title: "Tasks", Width: 6},
+		{Title: "Top process", Width: 30},
+		{Title: "Top CPU%", Width: 9},
+	}
+}
+
+func (d Dashboard) Init() tea.Cmd {
+	cmds := []tea.Cmd{d.tickEvery()}
+	for i := range d.hosts {
+		cmds = append(cmds, d.fetchSummary(i))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (d Dashboard) tickEvery() tea.Cmd {
+	gen := d.tickGen
+	return tea.Every(d.config.RefreshInterval, func(t time.Time) tea.Msg {
+		return TickMsg{Time: t, gen: gen}
+	})
+}
+
+func (d Dashboard) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := teaMsg.(type) {
+	case tea.WindowSizeMsg:
+		d.width = msg.Width
+		d.height = msg.Height
+		d.table.SetWidth(msg.Width)
+	case hostSummaryMsg:
+		// Summaries keep arriving while drilled down and are not forwarded.
+		d.summaries[msg.i] = msg.summary
+		d.fetching[msg.i] = false
+		d.hasLoaded = true
+		d.table.SetRows(d.rows())
+		return d, nil
+	}
+
+	keys := d.config.Keys
+	if d.detail != nil {
+		if msg, ok := teaMsg.(tea.KeyMsg); ok && key.Matches(msg, keys.Back) && d.detail.atTopLevel() && !d.detail.showHelp && !d.detail.prompting {
+			// Start a new tick loop, dropping the ticks and snapshots still
+			// pending from the detail view's loop.
+			d.tickGen = d.detail.tickGen + 1
+			d.detail = nil
+			return d, d.tickEvery()
+		}
+		detail, cmd := d.detail.Update(teaMsg)
+		m := detail.(Model)
+		d.detail = &m
+		return d, cmd
+	}
+
+	switch msg := teaMsg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return d, tea.Quit
+		case key.Matches(msg, keys.Up):
+			d.table.MoveUp(1)
+		case key.Matches(msg, keys.Down):
+			d.table.MoveDown(1)
+		case key.Matches(msg, keys.PageUp):
+			d.table.PageUp()
+		case key.Matches(msg, keys.PageDown):
+			d.table.PageDown()
+		case key.Matches(msg, keys.Top):
+			d.table.GotoTop()
+		case key.Matches(msg, keys.Bottom):
+			d.table.GotoBottom()
+		case key.Matches(msg, keys.Select):
+			return d.drillDown()
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			d.table.MoveUp(wheelRows)
+		case tea.MouseButtonWheelDown:
+			d.table.MoveDown(wheelRows)
+		case tea.MouseButtonLeft:
+			// The table starts below the padding line and the title.
+			if row, ok := d.table.RowAt(msg.Y - 2); ok {
+				d.table.SetCursor(row)
+			}
+		}
+
+	case TickMsg:
+		if msg.gen != d.tickGen {
+			return d, nil
+		}
+		cmds := []tea.Cmd{d.tickEvery()}
+		for i := range d.hosts {
+			if !d.fetching[i] {
+				d.fetching[i] = true
+				cmds = append(cmds, d.fetchSummary(i))
+			}
+		}
+		return d, tea.Batch(cmds...)
+	}
+
+	return d, nil
+}
+
+// drillDown switches to the single host view of the selected host and
+// loads it in the background. The dashboard's tick keeps running and is
+// forwarded to the detail view.
+func (d Dashboard) drillDown() (Dashboard, tea.Cmd) {
+	i := d.table.Cursor()
+	if i < 0 || i >= len(d.hosts) {
+		return d, nil
+	}
+
+	host := d.hosts[i]
+	m := NewModel(d.config, host.stats, host.processes)
+	m.tickGen = d.tickGen
+	m.width, m.height = d.width, d.height
+	m.processTable.SetWidth(d.width)
+	d.detail = &m
+
+	return d, m.fetchSnapshot(host.stats, time.Now(), true)
+}
+
+// hostSummaryMsg carries the summary of the host at index i.
+type hostSummaryMsg struct {
+	i       int
+	summary hostSummary
+}
+
+// fetchSummary fetches the summary of the host at index i in the background.
+// Each host answers on its own, so a slow agent only delays its own row.
+func (d Dashboard) fetchSummary(i int) tea.Cmd {
+	host := d.hosts[i]
+	return func() tea.Msg {
+		return hostSummaryMsg{i: i, summary: fetchHostSummary(host)}
+	}
+}
+
+// fetchHostSummary collects the overview of a single host in one snapshot
+// request, reporting the first error of the parts it needs.
+func fetchHostSummary(host dashboardHost) hostSummary {
+	summary := hostSummary{Addr: host.addr, Loaded: true}
+
+	resp, err := host.stats.snapshot(snapshotRequest{Options: ProcessOptions{SortBy: SortByCPU, Limit: 1}})
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	for _, part := range []string{"host", "cpu", "mem", "load", "processes"} {
+		if err := resp.err(part); err != nil {
+			summary.Err = err
+			return summary
+		}
+	}
+
+	summary.Hostname = resp.HostInfo.Hostname
+	summary.CPU = 100 - resp.CpuUsage.Idle
+	summary.Memory = resp.MemUsage.UsedPercent
+	summary.Load = [3]float64{resp.LoadAvg.Load1, resp.LoadAvg.Load5, resp.LoadAvg.Load15}
+	summary.Tasks = resp.Summary
+	if len(resp.Processes) > 0 {
+		summary.Top = &resp.Processes[0]
+	}
+
+	return summary
+}
+
+// rows builds the table rows of the host overview.
+func (d Dashboard) rows() []Row {
+	rows := make([]Row, 0, len(d.summaries))
+	for _, s := range d.summaries {
+		name := s.Hostname
+		if name == "" {
+			name = s.Addr
+		}
+
+		switch {
+		case !s.Loaded:
+			rows = append(rows, Row{name, "-", "-", "-", "-", "connecting…", "-"})
+			continue
+		case s.Err != nil:
+			rows = append(rows, Row{name, "-", "-", "-", "-", "unreachable: " + s.Err.Error(), "-"})
+			continue
+		}
+
+		top, topCPU := "-", "-"
+		if s.Top != nil {
+			top = fmt.Sprintf("%s (%d)", s.Top.Name, s.Top.PID)
+			topCPU = fmt.Sprintf("%.2f%%", s.Top.CPUPercent)
+		}
+
+		rows = append(rows, Row{
+			name,
+			fmt.Sprintf("%.1f%%", s.CPU),
+			fmt.Sprintf("%.1f%%", s.Memory),
+			fmt.Sprintf("%.2f %.2f %.2f", s.Load[0], s.Load[1], s.Load[2]),
+			fmt.Sprintf("%d", s.Tasks.Total),
+			top,
+			topCPU,
+		})
+	}
+	return rows
+}
+
+func (d Dashboard) View() string {
+	if d.detail != nil {
+		return d.detail.View()
+	}
+
+	style := lipgloss.NewStyle()
+	if !d.hasLoaded {
+		return style.Padding(1).Render("Loading...")
+	}
+
+	keys := d.config.Keys
+	keys.Select.SetHelp(keys.Select.Help().Key, "drill down")
+	bar := help.New()
+	bar.Width = d.width
+
+	title := style.Bold(true).Padding(0, 1).Render(fmt.Sprintf("%d hosts", len(d.hosts)))
+
+	return style.
+		Width(d.width).
+		Height(d.height).
+		Padding(1, 0, 0, 0).
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			d.table.View(),
+			style.Padding(1, 1, 0, 1).Render(bar.ShortHelpView([]key.Binding{keys.Up, keys.Down, keys.Select, keys.Quit})),
+		))
+}
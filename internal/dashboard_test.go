@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDashboardFetchesHostsInBackground(t *testing.T) {
+	server, _ := newTestAgent(t)
+
+	// An agent that accepts connections but never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	d := NewDashboard(*DefaultConfig(), []string{server.URL, listener.Addr().String()}, "t")
+
+	batch, ok := d.Init()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init did not return a batch of commands")
+	}
+	// The tick comes first, followed by one fetch per host.
+	if len(batch) != 3 {
+		t.Fatalf("Init returned %d commands, want a tick and 2 fetches", len(batch))
+	}
+
+	updated, _ := d.Update(batch[1]())
+	d = updated.(Dashboard)
+
+	rows := d.table.Rows()
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0][0] != "fake" || rows[0][4] != "10" || !strings.HasPrefix(rows[0][5], "proc-10") {
+		t.Errorf("row of the answering host = %v, want fake with 10 tasks and proc-10 on top", rows[0])
+	}
+	if rows[1][5] != "connecting…" {
+		t.Errorf("row of the silent host = %v, want it still connecting", rows[1])
+	}
+
+	// The silent host is still being fetched and is not queried again.
+	_, cmd := d.Update(TickMsg{Time: time.Now()})
+	if batch, ok := cmd().(tea.BatchMsg); !ok || len(batch) != 2 {
+		t.Errorf("tick returned %d commands, want the next tick and a fetch of the answering host", len(batch))
+	}
+}
+
+func TestDashboardEscClosesDetailPromptFirst(t *testing.T) {
+	server, _ := newTestAgent(t)
+	d := NewDashboard(*DefaultConfig(), []string{server.URL}, "t")
+
+	updated, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.Update(cmd())
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	if d = updated.(Dashboard); d.detail == nil || !d.detail.prompting {
+		t.Fatal("# did not open the PID prompt of the detail view")
+	}
+
+	updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d = updated.(Dashboard); d.detail == nil {
+		t.Fatal("esc left the detail view while the PID prompt was open")
+	}
+	if d.detail.prompting {
+		t.Error("esc did not close the PID prompt")
+	}
+
+	updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d = updated.(Dashboard); d.detail != nil {
+		t.Error("esc did not return to the overview once the prompt was closed")
+	}
+}
+
+func TestDashboardRestartsTicksOnLeavingHost(t *testing.T) {
+	server, _ := newTestAgent(t)
+	d := NewDashboard(*DefaultConfig(), []string{server.URL}, "t")
+
+	updated, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.Update(cmd())
+	// Slowing down the host view restarts its tick loop.
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	d = updated.(Dashboard)
+	detailGen := d.detail.tickGen
+
+	updated, cmd = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	d = updated.(Dashboard)
+	if d.detail != nil || cmd == nil {
+		t.Fatalf("esc left the host %t and started a tick %t, want both", d.detail == nil, cmd != nil)
+	}
+
+	// Ticks and snapshots of the host view's loops are dropped.
+	for _, msg := range []tea.Msg{
+		TickMsg{Time: time.Now(), gen: 0},
+		TickMsg{Time: time.Now(), gen: detailGen},
+		snapshotMsg{gen: detailGen},
+	} {
+		if _, cmd := d.Update(msg); cmd != nil {
+			t.Errorf("%T of an old loop returned a command", msg)
+		}
+	}
+	if _, cmd := d.Update(TickMsg{Time: time.Now(), gen: d.tickGen}); cmd == nil {
+		t.Error("tick of the overview's loop returned no command")
+	}
+}
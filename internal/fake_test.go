@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// fakeStats is a StatsFetcher returning fixed stats of a host without PSI.
+type fakeStats struct{}
+
+func (fakeStats) HostInfo() (*host.InfoStat, error) {
+	return &host.InfoStat{Hostname: "fake", OS: "linux", Platform: "test", Uptime: 3600}, nil
+}
+
+func (fakeStats) CpuUsage() (*cpu.TimesStat, error) {
+	return &cpu.TimesStat{CPU: "cpu-total", User: 30, System: 10, Idle: 50, Iowait: 5, Steal: 5}, nil
+}
+
+func (fakeStats) MemUsage() (*mem.VirtualMemoryStat, error) {
+	return &mem.VirtualMemoryStat{Total: 8 << 30, Used: 2 << 30, UsedPercent: 25}, nil
+}
+
+func (fakeStats) SwapUsage() (*mem.SwapMemoryStat, error) {
+	return &mem.SwapMemoryStat{Total: 1 << 30}, nil
+}
+
+func (fakeStats) LoadAvg() (*load.AvgStat, error) {
+	return &load.AvgStat{Load1: 0.5, Load5: 0.25, Load15: 0.125}, nil
+}
+
+func (fakeStats) Pressure() (*PressureStat, error) {
+	return nil, fmt.Errorf("open /proc/pressure/cpu: %w", fs.ErrNotExist)
+}
+
+func (fakeStats) Sensors() (*SensorStat, error) {
+	return &SensorStat{}, nil
+}
+
+func (fakeStats) Power() (*PowerStat, error) {
+	return &PowerStat{}, nil
+}
+
+// fakeProcesses is a ProcessManager over a fixed process list. It has no
+// thread details, and the open files of every process are gone.
+type fakeProcesses struct {
+	processes []ProcessInfo
+	// opts records the options of the last GetProcesses call.
+	opts ProcessOptions
+}
+
+// newFakeProcesses returns a fakeProcesses with n processes named proc-<pid>,
+// using more CPU the higher their PID.
+func newFakeProcesses(n int) *fakeProcesses {
+	processes := make([]ProcessInfo, n)
+	for i := range processes {
+		pid := int32(i + 1)
+		processes[i] = ProcessInfo{
+			PID:         pid,
+			ParentPID:   1,
+			Name:        fmt.Sprintf("proc-%d", pid),
+			Username:    "root",
+			State:       StateSleeping,
+			NumThreads:  1,
+			CPUPercent:  float64(pid),
+			MemoryUsage: float64(n - i),
+		}
+	}
+	return &fakeProcesses{processes: processes}
+}
+
+func (f *fakeProcesses) GetProcesses(opts ProcessOptions) ([]ProcessInfo, error) {
+	f.opts = opts
+	return sortAndLimit(filterGroup(append([]ProcessInfo(nil), f.processes...), opts), opts), nil
+}
+
+func (f *fakeProcesses) GetGroups(opts ProcessOptions) ([]ProcessGroup, error) {
+	return sortAndLimitGroups(groupProcesses(f.processes, opts.GroupBy), opts), nil
+}
+
+func (f *fakeProcesses) TaskSummary() TaskSummary {
+	return summarizeTasks(f.processes)
+}
+
+func (f *fakeProcesses) GetThreads(pid int32) ([]ThreadInfo, error) {
+	return nil, ErrNotSupported
+}
+
+func (f *fakeProcesses) GetOpenFiles(pid int32) ([]OpenFile, error) {
+	return nil, &fs.PathError{Op: "open", Path: fmt.Sprintf("/proc/%d/fd", pid), Err: fs.ErrNotExist}
+}
+
+func (f *fakeProcesses) GetListeningPorts() ([]ListeningPort, error) {
+	return []ListeningPort{{Socket: Socket{Protocol: "tcp", LocalPort: 22}, PID: 1, Name: "proc-1"}}, nil
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -25,6 +26,37 @@ func ProgressBar(percentage float64, baseStyle lipgloss.Style, filledColor, empt
 	return baseStyle.Render(fmt.Sprintf("%s%s%s%s", "[", filled, empty, "]"))
 }
 
+// BarSegment is one colored part of a stacked bar.
+type BarSegment struct {
+	Percentage float64
+	Color      lipgloss.Color
+}
+
+// StackedBar renders several percentages as consecutive colored parts of a
+// single progress bar. Rounding never lets the segments overflow the bar.
+func StackedBar(segments []BarSegment, baseStyle lipgloss.Style, emptyColor lipgloss.Color) string {
+	totalBars := 25
+
+	var b strings.Builder
+	used := 0
+	cumulative := 0.0
+	for _, segment := range segments {
+		cumulative += segment.Percentage
+		end := min(int(cumulative/100*float64(totalBars)), totalBars)
+		if end <= used {
+			continue
+		}
+		b.WriteString(baseStyle.Foreground(segment.Color).Render(strings.Repeat("|", end-used)))
+		used = end
+	}
+
+	empty := baseStyle.
+		Foreground(emptyColor).
+		Render(strings.Repeat("|", totalBars-used))
+
+	return baseStyle.Render(fmt.Sprintf("%s%s%s%s", "[", b.String(), empty, "]"))
+}
+
 // timeToHuman converts seconds to a human-readable format.
 func timeToHuman(seconds uint64) string {
 	hours := seconds / 3600
@@ -38,6 +70,28 @@ func timeToHuman(seconds uint64) string {
 	return fmt.Sprintf("%02d hrs, %02d mins", hours, minutes)
 }
 
+// formatInterval formats a refresh interval such as "500ms", "2s" or "1m".
+func formatInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		return strings.TrimSuffix(s, "0s")
+	}
+	return s
+}
+
+// formatStartTime formats a process start time, leaving out the date for
+// processes started today. Unknown start times are shown as "-".
+func formatStartTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	now := time.Now()
+	if y, m, d := t.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("Jan 02 15:04:05")
+}
+
 // listItem formats a key-value pair with an optional suffix.
 // It aligns the value to the right and renders it with the specified style.
 func listItem(baseStyle lipgloss.Style, key string, value string, suffix ...string) string {
@@ -53,3 +107,18 @@ func listItem(baseStyle lipgloss.Style, key string, value string, suffix ...stri
 
 	return fmt.Sprintf("%6s %s", listItemKey(key), listItemValue)
 }
+
+// formatIORate formats a byte rate for the process table.
+// Rates that could not be read are shown as "-" to tell them apart from zero.
+func formatIORate(bytesPerSec float64, available bool) string {
+	if !available {
+		return "-"
+	}
+	return formatBytes(uint64(bytesPerSec)) + "/s"
+}
+
+// formatBytes formats a byte count compactly, e.g. "1.50GB".
+func formatBytes(bytes uint64) string {
+	value, unit := convertBytes(bytes)
+	return strings.TrimSpace(value) + unit
+}
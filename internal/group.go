@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"sort"
+	"time"
+)
+
+type GroupCriteria string
+
+const (
+	GroupByNone   GroupCriteria = ""
+	GroupByCgroup GroupCriteria = "cgroup"
+	GroupByUnit   GroupCriteria = "unit"
+	GroupByUser   GroupCriteria = "user"
+	GroupByName   GroupCriteria = "name"
+)
+
+// groupCriteriaOrder is the order in which the group key cycles through the criteria.
+var groupCriteriaOrder = []GroupCriteria{GroupByNone, GroupByCgroup, GroupByUnit, GroupByUser, GroupByName}
+
+// ProcessGroup aggregates the processes sharing the same group key.
+type ProcessGroup struct {
+	Key         string
+	Name        string
+	Count       int
+	Threads     int
+	CPUPercent  float64
+	MemoryUsage float64 // RSS in MB
+	// FirstStart and LastStart are the start times of the oldest and newest
+	// member, zero when no member has a known start time.
+	FirstStart time.Time
+	LastStart  time.Time
+	// Limits is set for cgroup groups when the cgroup filesystem is readable.
+	Limits *CgroupLimits
+}
+
+// unknownGroup is the key and name of the group of processes whose field
+// is empty, such as kernel threads without a cgroup. An empty key would be
+// mistaken for no group being selected in ProcessOptions.Group.
+const unknownGroup = "?"
+
+// groupKey returns the key and display name of the group p belongs to.
+func groupKey(p ProcessInfo, by GroupCriteria) (string, string) {
+	var key, name string
+	switch by {
+	case GroupByCgroup:
+		key, name = p.Cgroup, p.Cgroup
+		if p.Container != "" {
+			name = p.Container
+		}
+	case GroupByUnit:
+		name, key = systemdUnit(p.Cgroup)
+	case GroupByUser:
+		key, name = p.Username, p.Username
+	case GroupByName:
+		key, name = p.Name, p.Name
+	default:
+		return "", ""
+	}
+
+	if key == "" {
+		return unknownGroup, unknownGroup
+	}
+	if name == "" {
+		name = unknownGroup
+	}
+	return key, name
+}
+
+// groupProcesses aggregates the processes by the given criteria.
+func groupProcesses(processInfos []ProcessInfo, by GroupCriteria) []ProcessGroup {
+	index := make(map[string]int)
+	var groups []ProcessGroup
+
+	for _, p := range processInfos {
+		key, name := groupKey(p, by)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ProcessGroup{Key: key, Name: name})
+		}
+
+		g := &groups[i]
+		g.Count++
+		g.Threads += int(p.NumThreads)
+		g.CPUPercent += p.CPUPercent
+		g.MemoryUsage += p.MemoryUsage
+		if !p.StartTime.IsZero() {
+			if g.FirstStart.IsZero() || p.StartTime.Before(g.FirstStart) {
+				g.FirstStart = p.StartTime
+			}
+			if p.StartTime.After(g.LastStart) {
+				g.LastStart = p.StartTime
+			}
+		}
+	}
+
+	return groups
+}
+
+// filterGroup returns the members of the group opts.Group, or all processes when no group is set.
+func filterGroup(processInfos []ProcessInfo, opts ProcessOptions) []ProcessInfo {
+	if opts.GroupBy == GroupByNone || opts.Group == "" {
+		return processInfos
+	}
+
+	var members []ProcessInfo
+	for _, p := range processInfos {
+		if key, _ := groupKey(p, opts.GroupBy); key == opts.Group {
+			members = append(members, p)
+		}
+	}
+	return members
+}
+
+// sortAndLimitGroups sorts the groups according to opts and truncates them to opts.Limit.
+// Sorting by PID sorts groups by their number of processes, and groups
+// have no I/O totals so sorting by I/O falls back to CPU.
+func sortAndLimitGroups(groups []ProcessGroup, opts ProcessOptions) []ProcessGroup {
+	less := func(i, j int) bool { return false }
+	switch opts.SortBy {
+	case SortByCPU, SortByIO:
+		less = func(i, j int) bool { return groups[i].CPUPercent < groups[j].CPUPercent }
+	case SortByMemory:
+		less = func(i, j int) bool { return groups[i].MemoryUsage < groups[j].MemoryUsage }
+	case SortByName:
+		less = func(i, j int) bool { return groups[i].Name < groups[j].Name }
+	case SortByPID:
+		less = func(i, j int) bool { return groups[i].Count < groups[j].Count }
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if opts.Ascending {
+			return less(i, j)
+		}
+		return less(j, i)
+	})
+
+	if len(groups) > opts.Limit {
+		groups = groups[:opts.Limit]
+	}
+	return groups
+}
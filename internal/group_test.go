@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGroupEmptyKey(t *testing.T) {
+	processes := []ProcessInfo{
+		{PID: 1, Username: "root"},
+		{PID: 2},
+		{PID: 3},
+	}
+
+	groups := groupProcesses(processes, GroupByUser)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	if g := groups[1]; g.Key != unknownGroup || g.Name != unknownGroup || g.Count != 2 {
+		t.Errorf("group of processes without user = %+v, want key and name %q with 2 processes", g, unknownGroup)
+	}
+
+	members := filterGroup(processes, ProcessOptions{GroupBy: GroupByUser, Group: unknownGroup})
+	if len(members) != 2 || members[0].PID != 2 || members[1].PID != 3 {
+		t.Errorf("filterGroup(%q) = %+v, want PIDs 2 and 3", unknownGroup, members)
+	}
+}
+
+func TestGroupAggregates(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	processes := []ProcessInfo{
+		{PID: 1, Cgroup: "/docker/a", Container: "docker:a", NumThreads: 4, CPUPercent: 12.5, MemoryUsage: 100, StartTime: start.Add(time.Hour)},
+		{PID: 2, Cgroup: "/init.scope", NumThreads: 1, CPUPercent: 1, MemoryUsage: 10, StartTime: start},
+		{PID: 3, Cgroup: "/docker/a", Container: "docker:a", NumThreads: 2, CPUPercent: 7.5, MemoryUsage: 50.5, StartTime: start},
+		{PID: 4, Cgroup: "/docker/a", Container: "docker:a", NumThreads: 1},
+	}
+
+	groups := groupProcesses(processes, GroupByCgroup)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	want := ProcessGroup{
+		Key: "/docker/a", Name: "docker:a", Count: 3, Threads: 7, CPUPercent: 20, MemoryUsage: 150.5,
+		FirstStart: start, LastStart: start.Add(time.Hour),
+	}
+	if g := groups[0]; g.Key != want.Key || g.Name != want.Name || g.Count != want.Count || g.Threads != want.Threads ||
+		g.CPUPercent != want.CPUPercent || g.MemoryUsage != want.MemoryUsage ||
+		!g.FirstStart.Equal(want.FirstStart) || !g.LastStart.Equal(want.LastStart) {
+		t.Errorf("container group = %+v, want %+v", g, want)
+	}
+	if g := groups[1]; g.Name != "/init.scope" || g.Count != 1 || g.Threads != 1 {
+		t.Errorf("host group = %+v, want /init.scope with 1 process", g)
+	}
+}
+
+func TestProcfsGroupsByContainer(t *testing.T) {
+	manager := NewProcfsProcessManager(filepath.Join("testdata", "procfs"), filepath.Join("testdata", "sysfs"))
+
+	groups, err := manager.GetGroups(ProcessOptions{GroupBy: GroupByCgroup, SortBy: SortByPID, Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]ProcessGroup)
+	for _, g := range groups {
+		names[g.Name] = g
+	}
+	// The container and its defunct child share the docker scope.
+	if g, ok := names["docker:3f4e5a6b7c8d"]; !ok || g.Count != 2 || g.Threads != 5 {
+		t.Errorf("docker group = %+v, want 2 processes with 5 threads", g)
+	}
+	if g, ok := names["k8s:0f1e2d3c/aabbccddeeff"]; !ok || g.Count != 1 {
+		t.Errorf("kubernetes group = %+v, want 1 process", g)
+	}
+	if g, ok := names["podman:1234567890ab"]; !ok || g.Count != 1 {
+		t.Errorf("podman group = %+v, want 1 process", g)
+	}
+}
@@ -2,8 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // HeaderView handles rendering of the header section with system stats.
@@ -30,7 +33,9 @@ func (h *HeaderView) Render(m Model) string {
 	return h.viewStyle.Render(
 		lipgloss.JoinVertical(lipgloss.Top,
 			h.renderHostDetails(m),
+			h.renderTaskSummary(m),
 			h.renderStatsSection(m),
+			h.renderSensorsSection(m),
 		),
 	)
 }
@@ -41,8 +46,67 @@ func (h *HeaderView) renderHostDetails(m Model) string {
 		Height(1).
 		Padding(1, 1).Render
 
-	return hostDetails(fmt.Sprintf("Host: %s | OS: %s | Arch: %s | Uptime: %s",
-		m.HostInfo.Hostname, m.HostInfo.OS, m.HostInfo.KernelArch, timeToHuman(m.HostInfo.Uptime)))
+	details := fmt.Sprintf("Host: %s | OS: %s | Arch: %s | Uptime: %s | Refresh: %s",
+		m.HostInfo.Hostname, m.HostInfo.OS, m.HostInfo.KernelArch, timeToHuman(m.HostInfo.Uptime),
+		formatInterval(m.config.RefreshInterval))
+	if power := h.renderPower(m); power != "" {
+		details += " | " + power
+	}
+	return hostDetails(details)
+}
+
+// renderPower renders the battery and AC adapter state for the host line,
+// or "" on systems without batteries or AC adapter.
+func (h *HeaderView) renderPower(m Model) string {
+	if m.Power == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, b := range m.Power.Batteries {
+		label := "Battery"
+		if len(m.Power.Batteries) > 1 {
+			label = b.Name
+		}
+		text := fmt.Sprintf("%s: %.0f%%", label, b.Capacity)
+		if b.Status != "" {
+			text += " " + strings.ToLower(b.Status)
+		}
+		if b.Remaining > 0 {
+			text += fmt.Sprintf(", %s left", timeToHuman(uint64(b.Remaining.Seconds())))
+		}
+		parts = append(parts, text)
+	}
+	if m.Power.HasAC {
+		ac := "AC: offline"
+		if m.Power.ACOnline {
+			ac = "AC: online"
+		}
+		parts = append(parts, ac)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// renderTaskSummary renders the task counts by process state.
+// Uninterruptible and zombie counts are highlighted when non-zero.
+func (h *HeaderView) renderTaskSummary(m Model) string {
+	count := func(n int, label string, color lipgloss.Color) string {
+		text := fmt.Sprintf("%d %s", n, label)
+		if n > 0 && color != "" {
+			return h.baseStyle.Foreground(color).Bold(true).Render(text)
+		}
+		return text
+	}
+
+	s := m.TaskSummary
+	return h.baseStyle.Padding(0, 1, 1, 1).Render(fmt.Sprintf("Tasks: %d total, %s, %d sleeping, %s, %s, %s",
+		s.Total,
+		count(s.Running, "running", ""),
+		s.Sleeping,
+		count(s.Uninterruptible, "uninterruptible", m.config.Colors.StateUninterruptible),
+		count(s.Stopped, "stopped", ""),
+		count(s.Zombie, "zombie", m.config.Colors.StateZombie),
+	))
 }
 
 // renderStatsSection renders all the stats columns (Usage, CPU, Memory, Load Avg).
@@ -55,6 +119,17 @@ func (h *HeaderView) renderStatsSection(m Model) string {
 	)
 }
 
+// cpuRegion locates the CPU panel within the rendered header.
+func (h *HeaderView) cpuRegion(m Model) region {
+	cpuColumn := h.renderCPUColumn(m)
+	return region{
+		x:      lipgloss.Width(h.renderUsageColumn(m)),
+		y:      lipgloss.Height(h.renderHostDetails(m)) + lipgloss.Height(h.renderTaskSummary(m)),
+		width:  lipgloss.Width(cpuColumn),
+		height: lipgloss.Height(cpuColumn),
+	}
+}
+
 // renderUsageColumn renders the usage progress bars column.
 func (h *HeaderView) renderUsageColumn(m Model) string {
 	list := h.createListStyle()
@@ -63,24 +138,77 @@ func (h *HeaderView) renderUsageColumn(m Model) string {
 	return list.Render(
 		lipgloss.JoinVertical(lipgloss.Left,
 			listHeader("% Usage"),
-			listItem(h.baseStyle, "CPU", fmt.Sprintf("%s %.1f", ProgressBar(100-m.CpuUsage.Idle, h.baseStyle, m.config.Colors.ProgressBarFilled, m.config.Colors.ProgressBarEmpty), 100-m.CpuUsage.Idle), "%"),
+			listItem(h.baseStyle, "CPU", fmt.Sprintf("%s %.1f", h.cpuBar(m), 100-m.CpuUsage.Idle), "%"),
 			listItem(h.baseStyle, "MEM", fmt.Sprintf("%s %.1f", ProgressBar(m.MemUsage.UsedPercent, h.baseStyle, m.config.Colors.ProgressBarFilled, m.config.Colors.ProgressBarEmpty), m.MemUsage.UsedPercent), "%"),
 			listItem(h.baseStyle, "SWAP", fmt.Sprintf("%s %.1f", ProgressBar(m.SwapUsage.UsedPercent, h.baseStyle, m.config.Colors.ProgressBarFilled, m.config.Colors.ProgressBarEmpty), m.SwapUsage.UsedPercent), "%"),
 		),
 	)
 }
 
-// renderCPUColumn renders the CPU stats column.
+// cpuBar renders the CPU usage as a bar stacked by CPU time category.
+func (h *HeaderView) cpuBar(m Model) string {
+	c, colors := m.CpuUsage, m.config.Colors
+	return StackedBar([]BarSegment{
+		{c.User - c.Guest, colors.CPUUser},
+		{c.Nice - c.GuestNice, colors.CPUNice},
+		{c.System, colors.CPUSystem},
+		{c.Irq + c.Softirq, colors.CPUIrq},
+		{c.Iowait, colors.CPUIowait},
+		{c.Steal, colors.CPUSteal},
+		{c.Guest + c.GuestNice, colors.CPUGuest},
+	}, h.baseStyle, colors.ProgressBarEmpty)
+}
+
+// renderCPUColumn renders the CPU stats column. When expanded with the c key
+// it shows every CPU time category, with labels colored like the usage bar.
 func (h *HeaderView) renderCPUColumn(m Model) string {
 	list := h.createListStyle().Border(lipgloss.NormalBorder(), false, true, false, false)
 	listHeader := h.baseStyle.Bold(true).Render
+	c, colors := m.CpuUsage, m.config.Colors
+
+	item := func(label string, value float64, color lipgloss.Color) string {
+		style := h.baseStyle
+		if color != "" {
+			style = style.Foreground(color)
+		}
+		return listItem(style, label, fmt.Sprintf("%5.2f", value), "%")
+	}
+
+	if !m.cpuExpanded {
+		return list.Render(
+			lipgloss.JoinVertical(lipgloss.Left,
+				listHeader("CPU ▸"),
+				item("User", c.User, ""),
+				item("Sys ", c.System, ""),
+				item("Idle", c.Idle, ""),
+			),
+		)
+	}
+
+	column := func(items ...string) string {
+		return h.baseStyle.PaddingRight(1).Render(lipgloss.JoinVertical(lipgloss.Left, items...))
+	}
 
 	return list.Render(
 		lipgloss.JoinVertical(lipgloss.Left,
-			listHeader("CPU"),
-			listItem(h.baseStyle, "User", fmt.Sprintf("%5.2f", m.CpuUsage.User), "%"),
-			listItem(h.baseStyle, "Sys ", fmt.Sprintf("%5.2f", m.CpuUsage.System), "%"),
-			listItem(h.baseStyle, "Idle", fmt.Sprintf("%5.2f", m.CpuUsage.Idle), "%"),
+			listHeader("CPU ▾"),
+			lipgloss.JoinHorizontal(lipgloss.Top,
+				column(
+					item("User", c.User, colors.CPUUser),
+					item("Nice", c.Nice, colors.CPUNice),
+					item("Sys ", c.System, colors.CPUSystem),
+				),
+				column(
+					item("Wait", c.Iowait, colors.CPUIowait),
+					item(" IRQ", c.Irq, colors.CPUIrq),
+					item("SIRQ", c.Softirq, colors.CPUIrq),
+				),
+				column(
+					item("Steal", c.Steal, colors.CPUSteal),
+					item("Guest", c.Guest+c.GuestNice, colors.CPUGuest),
+					item(" Idle", c.Idle, ""),
+				),
+			),
 		),
 	)
 }
@@ -115,6 +243,133 @@ func (h *HeaderView) renderLoadAvgColumn(m Model) string {
 	)
 }
 
+// renderSensorsSection renders the pressure, CPU frequency and temperature panels.
+// The frequency and temperature panels are left out when sysfs exposes no such sensors.
+func (h *HeaderView) renderSensorsSection(m Model) string {
+	panels := []string{h.renderPressureSection(m)}
+	if m.Sensors != nil && len(m.Sensors.CPUFreqs) > 0 {
+		panels = append(panels, h.renderFrequencyColumn(m))
+	}
+	if m.Sensors != nil && len(m.Sensors.Temperatures) > 0 {
+		panels = append(panels, h.renderTemperatureColumn(m))
+	}
+	return h.baseStyle.PaddingTop(1).Render(lipgloss.JoinHorizontal(lipgloss.Top, panels...))
+}
+
+// renderPressureSection renders the Pressure Stall Information panel.
+// Each resource shows a bar for the "some" 10s average followed by the
+// some and full 10s/60s/300s averages.
+func (h *HeaderView) renderPressureSection(m Model) string {
+	list := h.createListStyle()
+	listHeader := h.baseStyle.Bold(true).Render
+
+	if m.Pressure == nil {
+		return list.Render(lipgloss.JoinVertical(lipgloss.Left,
+			listHeader("Pressure"),
+			"not available on this kernel",
+		))
+	}
+
+	item := func(label string, r PressureResource) string {
+		bar := ProgressBar(r.Some.Avg10, h.baseStyle, m.config.Colors.ProgressBarFilled, m.config.Colors.ProgressBarEmpty)
+		return listItem(h.baseStyle, label, fmt.Sprintf("%s %6.2f %6.2f %6.2f │ %6.2f %6.2f %6.2f",
+			bar, r.Some.Avg10, r.Some.Avg60, r.Some.Avg300, r.Full.Avg10, r.Full.Avg60, r.Full.Avg300), "%")
+	}
+
+	return list.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			listHeader(fmt.Sprintf("%-34s %6s %6s %6s │ %6s %6s %6s", "Pressure (some, full)", "10s", "60s", "300s", "10s", "60s", "300s")),
+			item("CPU", m.Pressure.CPU),
+			item("MEM", m.Pressure.Memory),
+			item("IO", m.Pressure.IO),
+		),
+	)
+}
+
+// maxCoreFrequencies is the number of CPUs up to which every core's frequency
+// is shown. Larger machines show the minimum, average and maximum instead.
+const maxCoreFrequencies = 12
+
+// renderFrequencyColumn renders the current CPU frequencies in MHz.
+func (h *HeaderView) renderFrequencyColumn(m Model) string {
+	list := h.createListStyle()
+	listHeader := h.baseStyle.Bold(true).Render
+	freqs := m.Sensors.CPUFreqs
+
+	var items []string
+	if len(freqs) <= maxCoreFrequencies {
+		for cpu, freq := range freqs {
+			items = append(items, listItem(h.baseStyle, fmt.Sprintf("cpu%d", cpu), fmt.Sprintf("%4.0f", freq)))
+		}
+	} else {
+		lowest, highest, sum := freqs[0], freqs[0], 0.0
+		for _, freq := range freqs {
+			lowest, highest, sum = min(lowest, freq), max(highest, freq), sum+freq
+		}
+		items = []string{
+			listItem(h.baseStyle, "min", fmt.Sprintf("%4.0f", lowest)),
+			listItem(h.baseStyle, "avg", fmt.Sprintf("%4.0f", sum/float64(len(freqs)))),
+			listItem(h.baseStyle, "max", fmt.Sprintf("%4.0f", highest)),
+		}
+	}
+
+	return list.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			listHeader("CPU MHz"),
+			h.gridColumns(items, 0),
+		),
+	)
+}
+
+// maxTemperatures is the number of temperature sensors shown in the header.
+const maxTemperatures = 9
+
+// renderTemperatureColumn renders the temperature sensors, colored by their
+// high and critical thresholds.
+func (h *HeaderView) renderTemperatureColumn(m Model) string {
+	list := h.createListStyle()
+	listHeader := h.baseStyle.Bold(true).Render
+
+	var items []string
+	for _, t := range m.Sensors.Temperatures {
+		high, critical := t.High, t.Critical
+		if high == 0 {
+			high = math.Inf(1)
+		}
+		if critical == 0 {
+			critical = math.Inf(1)
+		}
+		value := fmt.Sprintf("%.1f°C", t.Current)
+		style := thresholdStyle(m.config.Colors, high, critical)(value).Inherit(h.baseStyle)
+		items = append(items, runewidth.FillRight(runewidth.Truncate(t.Name, 14, "…"), 14)+" "+style.Render(fmt.Sprintf("%7s", value)))
+	}
+	if len(items) > maxTemperatures {
+		items = items[:maxTemperatures]
+	}
+
+	return list.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			listHeader("Temperature"),
+			h.gridColumns(items, 2),
+		),
+	)
+}
+
+// gridColumns lays out items top to bottom in columns of three rows,
+// separated by gap spaces.
+func (h *HeaderView) gridColumns(items []string, gap int) string {
+	var columns []string
+	for start := 0; start < len(items); start += 3 {
+		end := min(start+3, len(items))
+		column := lipgloss.JoinVertical(lipgloss.Left, items[start:end]...)
+		if end < len(items) {
+			column = h.baseStyle.PaddingRight(gap).Render(column)
+		}
+		columns = append(columns, column)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
 // createListStyle creates the base style for list containers.
 func (h *HeaderView) createListStyle() lipgloss.Style {
 	return h.baseStyle.
@@ -10,8 +10,50 @@ func (m Model) Init() tea.Cmd {
 	return m.tickEvery()
 }
 
+// tickEvery schedules the next tick of the current tick loop.
 func (m Model) tickEvery() tea.Cmd {
+	gen := m.tickGen
 	return tea.Every(m.config.RefreshInterval, func(t time.Time) tea.Msg {
-		return TickMsg(t)
+		return TickMsg{Time: t, gen: gen}
 	})
 }
+
+// restartTicks starts a new tick loop after the refresh interval changed,
+// so the next tick comes at the new interval rather than the old one.
+func (m Model) restartTicks() (Model, tea.Cmd) {
+	m.tickGen++
+	return m, m.tickEvery()
+}
+
+// refreshIntervals are the steps the refresh interval moves through at runtime.
+var refreshIntervals = []time.Duration{
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// nextRefreshInterval returns the next longer step after d, at most a minute.
+func nextRefreshInterval(d time.Duration) time.Duration {
+	for _, step := range refreshIntervals {
+		if step > d {
+			return step
+		}
+	}
+	return refreshIntervals[len(refreshIntervals)-1]
+}
+
+// previousRefreshInterval returns the next shorter step before d, at least 100ms.
+func previousRefreshInterval(d time.Duration) time.Duration {
+	for i := len(refreshIntervals) - 1; i >= 0; i-- {
+		if refreshIntervals[i] < d {
+			return refreshIntervals[i]
+		}
+	}
+	return refreshIntervals[0]
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds the key bindings of the process view. It is part of Config
+// so bindings can be remapped, and Model.keyMap enables the bindings that
+// apply to the current view, which also decides what the help shows.
+type KeyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	JumpToPID    key.Binding
+	Pin          key.Binding
+	Select       key.Binding
+	Back         key.Binding
+	Threads      key.Binding
+	OpenFiles    key.Binding
+	Ports        key.Binding
+	Group        key.Binding
+	Sort         key.Binding
+	Reverse      key.Binding
+	ExpandCPU    key.Binding
+	Pause        key.Binding
+	Step         key.Binding
+	Slower       key.Binding
+	Faster       key.Binding
+	Help         key.Binding
+	Quit         key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup", "ctrl+b"), key.WithHelp("pgup", "page up")),
+		PageDown:     key.NewBinding(key.WithKeys("pgdown", "ctrl+f"), key.WithHelp("pgdn", "page down")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "half page up")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "half page down")),
+		Top:          key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g/home", "go to top")),
+		Bottom:       key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G/end", "go to bottom")),
+		JumpToPID:    key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "jump to PID")),
+		Pin:          key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "pin process")),
+		Select:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		Back:         key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Threads:      key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "threads")),
+		OpenFiles:    key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "open files")),
+		Ports:        key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "listening ports")),
+		Group:        key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "change grouping")),
+		Sort:         key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "change sort")),
+		Reverse:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reverse sort")),
+		ExpandCPU:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "CPU details")),
+		Pause:        key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "pause")),
+		Step:         key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next tick")),
+		Slower:       key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "refresh slower")),
+		Faster:       key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "refresh faster")),
+		Help:         key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Quit:         key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// ShortHelp returns the bindings of the help bar. Disabled bindings are left out by help.Model.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Select, k.Back, k.Threads, k.OpenFiles, k.Ports, k.Group, k.Sort, k.JumpToPID, k.Pause, k.Step, k.Help, k.Quit}
+}
+
+// FullHelp returns the bindings of the help overlay, grouped by column.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown, k.Top, k.Bottom},
+		{k.Select, k.Back, k.JumpToPID, k.Pin},
+		{k.Threads, k.OpenFiles, k.Ports, k.Group},
+		{k.Sort, k.Reverse, k.ExpandCPU, k.Pause, k.Step, k.Slower, k.Faster, k.Help, k.Quit},
+	}
+}
+
+// named returns the bindings by the names used in the configuration file.
+func (k *KeyMap) named() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":             &k.Up,
+		"down":           &k.Down,
+		"page_up":        &k.PageUp,
+		"page_down":      &k.PageDown,
+		"half_page_up":   &k.HalfPageUp,
+		"half_page_down": &k.HalfPageDown,
+		"top":            &k.Top,
+		"bottom":         &k.Bottom,
+		"jump_to_pid":    &k.JumpToPID,
+		"pin":            &k.Pin,
+		"select":         &k.Select,
+		"back":           &k.Back,
+		"threads":        &k.Threads,
+		"open_files":     &k.OpenFiles,
+		"ports":          &k.Ports,
+		"group":          &k.Group,
+		"sort":           &k.Sort,
+		"reverse":        &k.Reverse,
+		"expand_cpu":     &k.ExpandCPU,
+		"pause":          &k.Pause,
+		"step":           &k.Step,
+		"slower":         &k.Slower,
+		"faster":         &k.Faster,
+		"help":           &k.Help,
+		"quit":           &k.Quit,
+	}
+}
+
+// Remap replaces the keys of the binding with the given name, keeping its description.
+func (k *KeyMap) Remap(name string, keys []string) error {
+	binding, ok := k.named()[name]
+	if !ok {
+		return fmt.Errorf("unknown key binding %q", name)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no keys given for key binding %q", name)
+	}
+	binding.SetKeys(keys...)
+	binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	return nil
+}
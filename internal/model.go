@@ -1,9 +1,12 @@
 package internal
 
 import (
+	"errors"
+	"slices"
 	"time"
 
-	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/host"
@@ -19,8 +22,8 @@ type Model struct {
 	statsFetcher StatsFetcher
 
 	lastUpdate   time.Time
-	processTable table.Model
-	tableStyle   table.Styles
+	processTable Table
+	tableStyle   TableStyles
 	baseStyle    lipgloss.Style
 	viewStyle    lipgloss.Style
 
@@ -29,44 +32,87 @@ type Model struct {
 	MemUsage  *mem.VirtualMemoryStat
 	SwapUsage *mem.SwapMemoryStat
 	LoadAvg   *load.AvgStat
+	// Pressure is nil when the kernel does not support PSI.
+	Pressure *PressureStat
+	Sensors  *SensorStat
+	Power    *PowerStat
+	help     help.Model
+	showHelp bool
+	// cpuExpanded shows every CPU time category instead of user, system and idle.
+	cpuExpanded bool
+
+	TaskSummary TaskSummary
 
 	processManager ProcessManager
 	processOptions ProcessOptions
+	processes      []ProcessInfo
+	// followPID is kept selected across refreshes while the list re-sorts.
+	// It is set by moving the cursor or jumping to a process.
+	followPID int32
+	// prompting is true while pidInput reads a PID to jump to.
+	// promptErr reports a failed jump until the next key press.
+	prompting bool
+	pidInput  textinput.Model
+	promptErr string
+
+	// paused freezes the display while ticks keep collecting into pending,
+	// oldest first.
+	paused  bool
+	pending []frame
+	// tickGen is the generation of the running tick loop.
+	tickGen int
+
+	// mode selects what the table shows. Sub-views such as modeThreads
+	// show details of the process detailPID.
+	mode       viewMode
+	detailPID  int32
+	detailName string
+	detailErr  error
+	threads    []ThreadInfo
+	openFiles  []OpenFile
+	ports      []ListeningPort
+
+	// groups holds the aggregated processes in modeGroups, and groupName
+	// the name of the group the process list is filtered to.
+	groups    []ProcessGroup
+	groupName string
 
 	hasLoaded bool
 }
 
-type TickMsg time.Time
+// TickMsg triggers a refresh. gen is the generation of the tick loop that
+// scheduled it: changing the refresh interval starts a new loop, and the
+// ticks still pending from the old one are dropped.
+type TickMsg struct {
+	Time time.Time
+	gen  int
+}
+
+// viewMode selects the content of the table.
+type viewMode int
+
+const (
+	modeProcesses viewMode = iota
+	modeThreads
+	modeOpenFiles
+	modePorts
+	modeGroups
+)
 
 func NewModel(config Config, fetcher StatsFetcher, processManager ProcessManager) Model {
-	tableStyle := table.DefaultStyles()
+	tableStyle := DefaultTableStyles()
 	tableStyle.Selected = lipgloss.NewStyle().Background(config.Colors.TableSelectionBackground)
 
-	// Creates a new table with specified columns and initial empty rows.
-	processTable := table.New(
-		// We use this to define our table "header"
-		table.WithColumns([]table.Column{
-			{Title: "PID", Width: 6},
-			{Title: "PPID", Width: 6},
-			{Title: "Name", Width: 30},
-			{Title: "CPU%", Width: 6},
-			{Title: "MEM%", Width: 6},
-			{Title: "MEM(MB)", Width: 10},
-			{Title: "Username", Width: 12},
-			{Title: "Time", Width: 12},
-		}),
-		table.WithRows([]table.Row{}),
-		table.WithFocused(true),
-		table.WithHeight(config.ProcessTableHeight),
-		table.WithStyles(tableStyle),
-	)
-
 	processOptions := ProcessOptions{
 		SortBy:    SortByCPU,
 		Limit:     config.ProcessLimit,
 		Ascending: false,
+		Pins:      slices.Clone(config.Pins),
 	}
 
+	// Creates a new table with the process columns and initial empty rows.
+	processTable := NewTable(processColumns(config, processOptions), config.ProcessTableHeight, tableStyle)
+
 	return Model{
 		config: config,
 
@@ -78,5 +124,164 @@ func NewModel(config Config, fetcher StatsFetcher, processManager ProcessManager
 
 		processManager: processManager,
 		processOptions: processOptions,
+
+		help:     help.New(),
+		pidInput: newPIDInput(),
+	}
+}
+
+// newPIDInput creates the input of the jump to PID prompt, accepting digits only.
+func newPIDInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Jump to PID: "
+	input.CharLimit = 10
+	input.Validate = func(s string) error {
+		for _, r := range s {
+			if r < '0' || r > '9' {
+				return errors.New("not a number")
+			}
+		}
+		return nil
+	}
+	return input
+}
+
+// processColumns defines the table "header" for the process list.
+// The column the processes are sorted by is marked with the sort direction.
+func processColumns(config Config, opts ProcessOptions) []Column {
+	title := func(title string, sortBy SortCriteria) string {
+		if sortBy != opts.SortBy {
+			return title
+		}
+		if opts.Ascending {
+			return title + "▲"
+		}
+		return title + "▼"
+	}
+
+	return []Column{
+		{Title: title("PID", SortByPID), Width: 6, Sort: SortByPID},
+		{Title: "PPID", Width: 6},
+		{Title: title("Name", SortByName), Width: 30, Sort: SortByName},
+		{Title: "S", Width: 1, Style: stateStyle(config.Colors)},
+		{Title: "THR", Width: 4},
+		{Title: title("CPU%", SortByCPU), Width: 6, Sort: SortByCPU},
+		{Title: "MEM%", Width: 6},
+		{Title: title("MEM(MB)", SortByMemory), Width: 10, Sort: SortByMemory},
+		{Title: title("READ/s", SortByIO), Width: 10, Sort: SortByIO},
+		{Title: title("WRITE/s", SortByIO), Width: 10, Sort: SortByIO},
+		{Title: "Username", Width: 12},
+		{Title: "Container", Width: 20},
+		{Title: "Time", Width: 12},
+	}
+}
+
+// threadColumns defines the table "header" for the thread list of a process.
+func threadColumns(config Config) []Column {
+	return []Column{
+		{Title: "TID", Width: 8},
+		{Title: "Name", Width: 30},
+		{Title: "S", Width: 1, Style: stateStyle(config.Colors)},
+		{Title: "CPU%", Width: 6},
+		{Title: "CPU", Width: 4},
+	}
+}
+
+// openFileColumns defines the table "header" for the open files of a process.
+func openFileColumns() []Column {
+	return []Column{
+		{Title: "FD", Width: 5},
+		{Title: "Type", Width: 10},
+		{Title: "Proto", Width: 5},
+		{Title: "Name", Width: 50},
+		{Title: "Remote", Width: 30},
+		{Title: "State", Width: 12},
+	}
+}
+
+// portColumns defines the table "header" for the listening ports view.
+func portColumns() []Column {
+	return []Column{
+		{Title: "Proto", Width: 5},
+		{Title: "Address", Width: 40},
+		{Title: "Port", Width: 6},
+		{Title: "PID", Width: 7},
+		{Title: "Name", Width: 30},
+		{Title: "State", Width: 8},
+	}
+}
+
+// groupColumns defines the table "header" for the process groups.
+// The column the groups are sorted by is marked with the sort direction.
+func groupColumns(config Config, opts ProcessOptions) []Column {
+	title := func(title string, sortBy ...SortCriteria) string {
+		if !slices.Contains(sortBy, opts.SortBy) {
+			return title
+		}
+		if opts.Ascending {
+			return title + "▲"
+		}
+		return title + "▼"
+	}
+
+	columns := []Column{
+		{Title: title(groupTitle(opts.GroupBy), SortByName), Width: 60, Sort: SortByName},
+		{Title: title("Procs", SortByPID), Width: 6, Sort: SortByPID},
+		{Title: "THR", Width: 6},
+		{Title: title("CPU%", SortByCPU, SortByIO), Width: 8, Sort: SortByCPU},
+		{Title: title("RSS", SortByMemory), Width: 12, Sort: SortByMemory},
+	}
+
+	// Cgroups also show their limits. Memory close to the limit and
+	// OOM kills are highlighted.
+	if opts.GroupBy == GroupByCgroup {
+		columns[0].Width = 40
+		columns = append(columns,
+			Column{Title: "MEM/MAX", Width: 20},
+			Column{Title: "MEM%MAX", Width: 7, Style: thresholdStyle(config.Colors, 75, 90)},
+			Column{Title: "CPU MAX", Width: 7},
+			Column{Title: "Throttled", Width: 10},
+			Column{Title: "PIDs", Width: 12},
+			Column{Title: "OOM", Width: 4, Style: thresholdStyle(config.Colors, 1, 1)},
+		)
+	}
+
+	// Instances of the same command show when the first and last were started.
+	if opts.GroupBy == GroupByName {
+		columns = append(columns,
+			Column{Title: "First start", Width: 16},
+			Column{Title: "Last start", Width: 16},
+		)
+	}
+	return columns
+}
+
+// groupTitle returns the title of the group name column for the given criteria.
+func groupTitle(by GroupCriteria) string {
+	switch by {
+	case GroupByCgroup:
+		return "Cgroup / Container"
+	case GroupByUnit:
+		return "Unit"
+	case GroupByUser:
+		return "User"
+	case GroupByName:
+		return "Name"
+	}
+	return "Group"
+}
+
+// columns returns the table columns for the current view mode.
+func (m Model) columns() []Column {
+	switch m.mode {
+	case modeThreads:
+		return threadColumns(m.config)
+	case modeOpenFiles:
+		return openFileColumns()
+	case modePorts:
+		return portColumns()
+	case modeGroups:
+		return groupColumns(m.config, m.processOptions)
 	}
+	return processColumns(m.config, m.processOptions)
 }
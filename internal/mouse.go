@@ -0,0 +1,99 @@
+package internal
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelRows is the number of rows a mouse wheel step moves the cursor.
+const wheelRows = 3
+
+// region is a rectangle of the screen, in cells.
+type region struct {
+	x, y          int
+	width, height int
+}
+
+// contains reports whether the cell at x, y lies within the region.
+func (r region) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+// screenLayout locates the parts of the view that react to the mouse.
+type screenLayout struct {
+	cpu region
+	// table starts with the header line of the table.
+	table region
+}
+
+// layout measures the rendered sections of the view. The header column
+// and the body column both start with a line of padding, and the table
+// fills the bottom of the body below the optional title line.
+func (m Model) layout() screenLayout {
+	header, body, _ := m.sections()
+
+	cpu := NewHeaderView(m.config, m.baseStyle, m.viewStyle).cpuRegion(m)
+	cpu.y++
+
+	return screenLayout{
+		cpu: cpu,
+		table: region{
+			y:      lipgloss.Height(header) + lipgloss.Height(body) - m.processTable.Height(),
+			width:  max(m.width, lipgloss.Width(body)),
+			height: m.processTable.Height(),
+		},
+	}
+}
+
+// updateMouse handles clicks on rows, column headers and the CPU panel,
+// and scrolls the table with the mouse wheel.
+func (m Model) updateMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || !m.hasLoaded || m.prompting || m.showHelp {
+		return m, nil
+	}
+
+	layout := m.layout()
+	focused := m.processTable.Focused()
+
+	switch {
+	case layout.table.contains(msg.X, msg.Y):
+		x, y := msg.X-layout.table.x, msg.Y-layout.table.y
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			if focused {
+				return m.moveCursor(func(t *Table) { t.MoveUp(wheelRows) }), nil
+			}
+		case tea.MouseButtonWheelDown:
+			if focused {
+				return m.moveCursor(func(t *Table) { t.MoveDown(wheelRows) }), nil
+			}
+		case tea.MouseButtonLeft:
+			if y == 0 {
+				return m.sortByColumn(m.processTable.ColumnAt(x))
+			}
+			if row, ok := m.processTable.RowAt(y); ok && focused {
+				return m.moveCursor(func(t *Table) { t.SetCursor(row) }), nil
+			}
+		}
+
+	case layout.cpu.contains(msg.X, msg.Y) && msg.Button == tea.MouseButtonLeft:
+		m.cpuExpanded = !m.cpuExpanded
+	}
+	return m, nil
+}
+
+// sortByColumn sorts the process list or the groups by the given column,
+// reversing the direction when they are already sorted by it.
+func (m Model) sortByColumn(col int) (Model, tea.Cmd) {
+	columns := m.processTable.Columns()
+	if m.mode != modeProcesses && m.mode != modeGroups || col < 0 || columns[col].Sort == "" {
+		return m, nil
+	}
+
+	if columns[col].Sort == m.processOptions.SortBy {
+		m.processOptions.Ascending = !m.processOptions.Ascending
+	} else {
+		m.processOptions.SortBy = columns[col].Sort
+	}
+	return m.applySort()
+}
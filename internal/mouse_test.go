@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newMouseTestModel returns a loaded model over 40 fake processes on a 120x40 terminal.
+func newMouseTestModel(t *testing.T) Model {
+	t.Helper()
+
+	var model tea.Model = NewModel(*DefaultConfig(), fakeStats{}, newFakeProcesses(40))
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model, _ = model.Update(TickMsg{Time: time.Now()})
+	return model.(Model)
+}
+
+// cellOf returns the screen cell of the first occurrence of text in the
+// rendered view of m, on the first line that also contains context.
+func cellOf(t *testing.T, m Model, context, text string) (int, int) {
+	t.Helper()
+
+	for y, line := range strings.Split(m.View(), "\n") {
+		if !strings.Contains(line, context) {
+			continue
+		}
+		if i := strings.Index(line, text); i >= 0 {
+			return lipgloss.Width(line[:i]), y
+		}
+	}
+	t.Fatalf("%q not found on a line with %q in:\n%s", text, context, m.View())
+	return 0, 0
+}
+
+// click sends a left click on the cell at x, y.
+func click(m Model, x, y int) Model {
+	updated, _ := m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	return updated.(Model)
+}
+
+func TestMouseClickRow(t *testing.T) {
+	m := newMouseTestModel(t)
+
+	x, y := cellOf(t, m, "proc-35", "proc-35")
+	m = click(m, x, y)
+
+	if cursor := m.processTable.Cursor(); cursor != 5 {
+		t.Errorf("cursor = %d after clicking the sixth row, want 5", cursor)
+	}
+	if p, ok := m.selectedProcess(); !ok || p.PID != 35 {
+		t.Errorf("selected PID %d, want 35", p.PID)
+	}
+}
+
+func TestMouseClickHeader(t *testing.T) {
+	m := newMouseTestModel(t)
+
+	x, y := cellOf(t, m, "PPID", "Name")
+	m = click(m, x, y)
+	if m.processOptions.SortBy != SortByName || m.processOptions.Ascending {
+		t.Errorf("sort = %s ascending %t after clicking Name, want name descending", m.processOptions.SortBy, m.processOptions.Ascending)
+	}
+
+	m = click(m, x, y)
+	if m.processOptions.SortBy != SortByName || !m.processOptions.Ascending {
+		t.Errorf("sort = %s ascending %t after clicking Name again, want name ascending", m.processOptions.SortBy, m.processOptions.Ascending)
+	}
+
+	// Columns without a sort criteria are ignored.
+	x, y = cellOf(t, m, "PPID", "PPID")
+	m = click(m, x, y)
+	if m.processOptions.SortBy != SortByName {
+		t.Errorf("sort = %s after clicking PPID, want it unchanged", m.processOptions.SortBy)
+	}
+}
+
+func TestMouseClickCPUPanel(t *testing.T) {
+	m := newMouseTestModel(t)
+
+	x, y := cellOf(t, m, "User:", "User:")
+	m = click(m, x, y)
+	if !m.cpuExpanded {
+		t.Fatal("clicking the CPU panel did not expand it")
+	}
+
+	// The expanded panel lists every CPU time category.
+	x, y = cellOf(t, m, "Steal", "Steal")
+	m = click(m, x, y)
+	if m.cpuExpanded {
+		t.Error("clicking the expanded CPU panel did not collapse it")
+	}
+
+	// Clicks on the usage bars next to the panel do nothing.
+	x, y = cellOf(t, m, "CPU:", "CPU:")
+	m = click(m, x, y)
+	if m.cpuExpanded {
+		t.Error("clicking the usage bars expanded the CPU panel")
+	}
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// Types of open file descriptors.
+const (
+	FileTypeFile      = "file"
+	FileTypeDevice    = "device"
+	FileTypePipe      = "pipe"
+	FileTypeSocket    = "socket"
+	FileTypeAnonInode = "anon_inode"
+	FileTypeUnknown   = "?"
+)
+
+// OpenFile describes a single file descriptor of a process.
+type OpenFile struct {
+	FD     int
+	Type   string
+	Target string
+	// Socket is set for sockets found in the socket tables of the process.
+	Socket *Socket
+	// Denied is true when the descriptor could not be resolved for lack of permissions.
+	Denied bool
+}
+
+// Socket describes an entry of the /proc/net socket tables.
+type Socket struct {
+	Protocol   string
+	LocalIP    string
+	LocalPort  uint16
+	RemoteIP   string
+	RemotePort uint16
+	Path       string // unix sockets only
+	State      string
+	Inode      uint64
+}
+
+// ListeningPort is a listening TCP or unconnected UDP socket and the process owning it.
+// PID is 0 when the owner could not be determined.
+type ListeningPort struct {
+	Socket
+	PID  int32
+	Name string
+}
+
+// LocalAddr returns the local address in host:port form, or the path for unix sockets.
+func (s Socket) LocalAddr() string {
+	if s.Protocol == "unix" {
+		return s.Path
+	}
+	return net.JoinHostPort(s.LocalIP, strconv.Itoa(int(s.LocalPort)))
+}
+
+// RemoteAddr returns the remote address in host:port form, or "" when unconnected.
+func (s Socket) RemoteAddr() string {
+	if s.Protocol == "unix" || s.RemotePort == 0 {
+		return ""
+	}
+	return net.JoinHostPort(s.RemoteIP, strconv.Itoa(int(s.RemotePort)))
+}
+
+// Name returns a short description of the file for the open files table.
+func (f OpenFile) Name() string {
+	switch {
+	case f.Denied:
+		return "permission denied"
+	case f.Socket != nil && f.Socket.Protocol == "unix" && f.Socket.Path == "":
+		return fmt.Sprintf("unix socket [%d]", f.Socket.Inode)
+	case f.Socket != nil:
+		return f.Socket.LocalAddr()
+	}
+	return f.Target
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"slices"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// maxPausedFrames bounds the ticks buffered while paused. Older ticks are dropped.
+const maxPausedFrames = 300
+
+// frame is the data collected on one tick, buffered while the display is paused.
+// It records the view it was collected for, since the rows depend on it.
+type frame struct {
+	time      time.Time
+	mode      viewMode
+	detailPID int32
+	options   ProcessOptions
+
+	HostInfo    *host.InfoStat
+	CpuUsage    *cpu.TimesStat
+	MemUsage    *mem.VirtualMemoryStat
+	SwapUsage   *mem.SwapMemoryStat
+	LoadAvg     *load.AvgStat
+	Pressure    *PressureStat
+	Sensors     *SensorStat
+	Power       *PowerStat
+	TaskSummary TaskSummary
+
+	processes []ProcessInfo
+	groups    []ProcessGroup
+	ports     []ListeningPort
+	threads   []ThreadInfo
+	openFiles []OpenFile
+	detailErr error
+}
+
+// bufferFrame collects the stats of a tick without changing what is shown.
+// Collection keeps going while paused so CPU and I/O rates stay accurate.
+func (m Model) bufferFrame(t time.Time) Model {
+	next := m.updateStats()
+	next.lastUpdate = t
+	return m.pushFrame(next.frame())
+}
+
+// pushFrame buffers f, dropping the oldest frames beyond maxPausedFrames.
+func (m Model) pushFrame(f frame) Model {
+	m.pending = append(m.pending, f)
+	if len(m.pending) > maxPausedFrames {
+		m.pending = m.pending[len(m.pending)-maxPausedFrames:]
+	}
+	return m
+}
+
+// receiveFrame shows a frame fetched in the background, or buffers it while
+// paused. A frame fetched for a view that was left in the meantime is dropped.
+func (m Model) receiveFrame(f frame) Model {
+	if m.paused {
+		return m.pushFrame(f)
+	}
+	if !m.showsView(f) {
+		return m
+	}
+	m = m.showFrame(f)
+	m.hasLoaded = true
+	return m
+}
+
+// frame returns the collected data of the model.
+func (m Model) frame() frame {
+	return frame{
+		time:        m.lastUpdate,
+		mode:        m.mode,
+		detailPID:   m.detailPID,
+		options:     m.processOptions,
+		HostInfo:    m.HostInfo,
+		CpuUsage:    m.CpuUsage,
+		MemUsage:    m.MemUsage,
+		SwapUsage:   m.SwapUsage,
+		LoadAvg:     m.LoadAvg,
+		Pressure:    m.Pressure,
+		Sensors:     m.Sensors,
+		Power:       m.Power,
+		TaskSummary: m.TaskSummary,
+		processes:   m.processes,
+		groups:      m.groups,
+		ports:       m.ports,
+		threads:     m.threads,
+		openFiles:   m.openFiles,
+		detailErr:   m.detailErr,
+	}
+}
+
+// showsView reports whether the frame was collected for the current view.
+// Frames collected before the view was changed while paused are not shown.
+func (m Model) showsView(f frame) bool {
+	return f.mode == m.mode && f.detailPID == m.detailPID &&
+		f.options.SortBy == m.processOptions.SortBy && f.options.Ascending == m.processOptions.Ascending &&
+		f.options.GroupBy == m.processOptions.GroupBy && f.options.Group == m.processOptions.Group &&
+		slices.Equal(f.options.Pins, m.processOptions.Pins)
+}
+
+// showFrame displays a buffered frame.
+func (m Model) showFrame(f frame) Model {
+	m.lastUpdate = f.time
+	m.HostInfo = f.HostInfo
+	m.CpuUsage = f.CpuUsage
+	m.MemUsage = f.MemUsage
+	m.SwapUsage = f.SwapUsage
+	m.LoadAvg = f.LoadAvg
+	m.Pressure = f.Pressure
+	m.Sensors = f.Sensors
+	m.Power = f.Power
+	m.TaskSummary = f.TaskSummary
+	m.processes = f.processes
+	m.groups = f.groups
+	m.ports = f.ports
+	m.threads = f.threads
+	m.openFiles = f.openFiles
+	m.detailErr = f.detailErr
+	return m.refreshTable()
+}
+
+// togglePause freezes the display, or resumes it with the latest buffered tick.
+func (m Model) togglePause() Model {
+	if !m.paused {
+		m.paused = true
+		return m
+	}
+
+	m.paused = false
+	if n := len(m.pending); n > 0 && m.showsView(m.pending[n-1]) {
+		m = m.showFrame(m.pending[n-1])
+	}
+	m.pending = nil
+	return m
+}
+
+// stepFrame shows the oldest buffered tick of the current view while staying paused.
+func (m Model) stepFrame() Model {
+	for len(m.pending) > 0 {
+		f := m.pending[0]
+		m.pending = m.pending[1:]
+		if m.showsView(f) {
+			return m.showFrame(f)
+		}
+	}
+	return m
+}
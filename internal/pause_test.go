@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pauseTest drives a loaded model whose busiest process is renamed on every
+// tick, so each frame shows which tick it was collected on.
+type pauseTest struct {
+	t         *testing.T
+	m         Model
+	processes *fakeProcesses
+	start     time.Time
+	ticks     int
+}
+
+func newPauseTest(t *testing.T) *pauseTest {
+	p := &pauseTest{t: t, processes: newFakeProcesses(5), start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var model tea.Model = NewModel(*DefaultConfig(), fakeStats{}, p.processes)
+	p.m = model.(Model)
+	p.update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.tick()
+	return p
+}
+
+func (p *pauseTest) update(msg tea.Msg) {
+	updated, _ := p.m.Update(msg)
+	p.m = updated.(Model)
+}
+
+func (p *pauseTest) press(r rune) {
+	p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+}
+
+// tick collects the next tick, naming the busiest process after it.
+func (p *pauseTest) tick() {
+	p.processes.processes[4].Name = fmt.Sprintf("tick-%d", p.ticks)
+	p.update(TickMsg{Time: p.start.Add(time.Duration(p.ticks) * time.Second)})
+	p.ticks++
+}
+
+// shownTick returns the tick whose frame is displayed.
+func (p *pauseTest) shownTick() string {
+	p.t.Helper()
+	if name := p.m.processTable.Rows()[0][2]; strings.HasPrefix(name, "tick-") {
+		return name
+	}
+	p.t.Fatalf("top row %v does not name a tick", p.m.processTable.Rows()[0])
+	return ""
+}
+
+// screen returns the header and the table, which do not include the pause indicator.
+func (p *pauseTest) screen() string {
+	header, body, _ := p.m.sections()
+	return header + body
+}
+
+func TestPauseKeepsDisplay(t *testing.T) {
+	p := newPauseTest(t)
+	p.press('z')
+	before := p.screen()
+
+	for range 3 {
+		p.tick()
+	}
+	if p.screen() != before {
+		t.Error("ticks while paused changed the display")
+	}
+	if got := p.shownTick(); got != "tick-0" {
+		t.Errorf("shown %s while paused, want tick-0", got)
+	}
+	if !strings.Contains(p.m.View(), "paused, 3 ticks behind") {
+		t.Errorf("footer does not show 3 ticks behind:\n%s", p.m.View())
+	}
+
+	// Resuming shows the latest tick.
+	p.press('z')
+	if got := p.shownTick(); got != "tick-3" || len(p.m.pending) != 0 {
+		t.Errorf("shown %s with %d pending ticks after resuming, want tick-3 and none", got, len(p.m.pending))
+	}
+	if strings.Contains(p.m.View(), "paused") {
+		t.Error("footer still shows the pause indicator after resuming")
+	}
+}
+
+func TestPauseStepsOldestFirst(t *testing.T) {
+	p := newPauseTest(t)
+	p.press('z')
+	for range 3 {
+		p.tick()
+	}
+
+	for i := 1; i <= 3; i++ {
+		p.press('n')
+		if got, want := p.shownTick(), fmt.Sprintf("tick-%d", i); got != want {
+			t.Errorf("step %d shown %s, want %s", i, got, want)
+		}
+		if got, want := p.m.lastUpdate, p.start.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+			t.Errorf("step %d last update %s, want %s", i, got, want)
+		}
+		if want := fmt.Sprintf("paused, %d ticks behind", 3-i); !strings.Contains(p.m.View(), want) {
+			t.Errorf("step %d footer does not show %q", i, want)
+		}
+	}
+
+	// Nothing is left to step through.
+	p.press('n')
+	if got := p.shownTick(); got != "tick-3" || !p.m.paused {
+		t.Errorf("stepping past the buffer shown %s, paused %t, want tick-3 and still paused", got, p.m.paused)
+	}
+}
+
+func TestPauseDropsOldestBeyondCap(t *testing.T) {
+	p := newPauseTest(t)
+	p.press('z')
+	for range maxPausedFrames + 5 {
+		p.tick()
+	}
+
+	if len(p.m.pending) != maxPausedFrames {
+		t.Fatalf("buffered %d ticks, want %d", len(p.m.pending), maxPausedFrames)
+	}
+	if want := fmt.Sprintf("paused, %d ticks behind", maxPausedFrames); !strings.Contains(p.m.View(), want) {
+		t.Errorf("footer does not show %q", want)
+	}
+
+	// Ticks 1 to 5 were dropped.
+	p.press('n')
+	if got := p.shownTick(); got != "tick-6" {
+		t.Errorf("first step shown %s, want tick-6", got)
+	}
+}
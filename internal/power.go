@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Battery describes a single battery from /sys/class/power_supply.
+type Battery struct {
+	Name     string
+	Capacity float64 // percent
+	Status   string  // Charging, Discharging, Full or Not charging
+	// Remaining is the time until empty when discharging or until full when
+	// charging, and 0 when the driver does not report the current draw.
+	Remaining time.Duration
+}
+
+// PowerStat holds the batteries and AC adapter state.
+type PowerStat struct {
+	Batteries []Battery
+	// HasAC is false when the system exposes no mains power supply.
+	HasAC    bool
+	ACOnline bool
+}
+
+// readPower reads the power supplies below the sysfs root.
+func readPower(sysfsRoot string) *PowerStat {
+	supplies, _ := filepath.Glob(filepath.Join(sysfsRoot, "class", "power_supply", "*"))
+	sort.Strings(supplies)
+
+	power := &PowerStat{}
+	for _, dir := range supplies {
+		switch readSysfsString(filepath.Join(dir, "type")) {
+		case "Mains":
+			power.HasAC = true
+			if readSysfsString(filepath.Join(dir, "online")) == "1" {
+				power.ACOnline = true
+			}
+		case "Battery":
+			if readSysfsString(filepath.Join(dir, "present")) == "0" {
+				continue
+			}
+			power.Batteries = append(power.Batteries, readBattery(dir))
+		}
+	}
+	return power
+}
+
+// readBattery reads a battery, estimating the remaining time from either the
+// energy (µWh, µW) or the charge (µAh, µA) attributes, whichever the driver provides.
+func readBattery(dir string) Battery {
+	battery := Battery{
+		Name:   filepath.Base(dir),
+		Status: readSysfsString(filepath.Join(dir, "status")),
+	}
+
+	now, full, rate, ok := readBatteryLevels(dir, "energy", "power")
+	if !ok {
+		now, full, rate, ok = readBatteryLevels(dir, "charge", "current")
+	}
+
+	if capacity, found := readSysfsFloat(filepath.Join(dir, "capacity")); found {
+		battery.Capacity = capacity
+	} else if ok && full > 0 {
+		battery.Capacity = now / full * 100
+	}
+
+	if ok && rate > 0 {
+		switch battery.Status {
+		case "Discharging":
+			battery.Remaining = time.Duration(now / rate * float64(time.Hour))
+		case "Charging":
+			battery.Remaining = time.Duration(max(full-now, 0) / rate * float64(time.Hour))
+		}
+	}
+	return battery
+}
+
+// readBatteryLevels reads the <level>_now, <level>_full and <rate>_now attributes.
+func readBatteryLevels(dir, level, rate string) (float64, float64, float64, bool) {
+	now, ok := readSysfsFloat(filepath.Join(dir, level+"_now"))
+	if !ok {
+		return 0, 0, 0, false
+	}
+	full, _ := readSysfsFloat(filepath.Join(dir, level+"_full"))
+	r, _ := readSysfsFloat(filepath.Join(dir, rate+"_now"))
+	// Some drivers report the discharge rate as a negative value.
+	if r < 0 {
+		r = -r
+	}
+	return now, full, r, true
+}
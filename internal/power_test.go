@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSysfsFiles creates the files below root, keyed by their path relative to root.
+func writeSysfsFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadPowerDischarging(t *testing.T) {
+	power := readPower(filepath.Join("testdata", "sysfs"))
+
+	if !power.HasAC || power.ACOnline {
+		t.Errorf("HasAC, ACOnline = %t, %t, want true, false", power.HasAC, power.ACOnline)
+	}
+	if len(power.Batteries) != 1 {
+		t.Fatalf("got %d batteries, want 1", len(power.Batteries))
+	}
+
+	battery := power.Batteries[0]
+	if battery.Name != "BAT0" || battery.Status != "Discharging" || battery.Capacity != 87 {
+		t.Errorf("battery = %+v, want BAT0 discharging at 87%%", battery)
+	}
+	// 43.5 Wh left at a draw of 12 W.
+	if got, want := battery.Remaining.Truncate(time.Minute), 3*time.Hour+37*time.Minute; got != want {
+		t.Errorf("Remaining = %s, want %s", got, want)
+	}
+}
+
+func TestReadPowerChargeFallback(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"class/power_supply/BAT0/type":        "Battery",
+		"class/power_supply/BAT0/present":     "1",
+		"class/power_supply/BAT0/status":      "Charging",
+		"class/power_supply/BAT0/charge_now":  "2000000",
+		"class/power_supply/BAT0/charge_full": "4000000",
+		// Some drivers report the current as a negative value.
+		"class/power_supply/BAT0/current_now": "-500000",
+	})
+
+	power := readPower(root)
+	if power.HasAC {
+		t.Error("HasAC = true for a system without a mains supply")
+	}
+	if len(power.Batteries) != 1 {
+		t.Fatalf("got %d batteries, want 1", len(power.Batteries))
+	}
+
+	battery := power.Batteries[0]
+	if battery.Capacity != 50 {
+		t.Errorf("Capacity = %v, want 50 from charge_now and charge_full", battery.Capacity)
+	}
+	if battery.Remaining != 4*time.Hour {
+		t.Errorf("Remaining = %s, want 4h0m0s until full", battery.Remaining)
+	}
+}
+
+func TestReadPowerBatteryNotPresent(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"class/power_supply/BAT0/type":     "Battery",
+		"class/power_supply/BAT0/present":  "1",
+		"class/power_supply/BAT0/status":   "Full",
+		"class/power_supply/BAT0/capacity": "100",
+		"class/power_supply/BAT1/type":     "Battery",
+		"class/power_supply/BAT1/present":  "0",
+		"class/power_supply/BAT1/capacity": "0",
+	})
+
+	power := readPower(root)
+	if len(power.Batteries) != 1 || power.Batteries[0].Name != "BAT0" {
+		t.Errorf("Batteries = %+v, want only BAT0", power.Batteries)
+	}
+}
+
+func TestReadPowerACOnly(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"class/power_supply/ADP1/type":   "Mains",
+		"class/power_supply/ADP1/online": "1",
+	})
+
+	power := readPower(root)
+	if !power.HasAC || !power.ACOnline {
+		t.Errorf("HasAC, ACOnline = %t, %t, want true, true", power.HasAC, power.ACOnline)
+	}
+	if len(power.Batteries) != 0 {
+		t.Errorf("Batteries = %+v, want none", power.Batteries)
+	}
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// PressureLine holds the running averages of one line of a PSI file,
+// as the percentage of time tasks were stalled.
+type PressureLine struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+}
+
+// PressureResource holds the "some" and "full" lines of a PSI file.
+// Some is time at least one task was stalled, Full time all tasks were.
+type PressureResource struct {
+	Some PressureLine
+	Full PressureLine
+}
+
+// PressureStat holds the Pressure Stall Information for CPU, memory and I/O.
+type PressureStat struct {
+	CPU    PressureResource
+	Memory PressureResource
+	IO     PressureResource
+}
+
+// readPressure reads the PSI files in the pressure directory of the procfs root.
+// It returns an error wrapping os.ErrNotExist on kernels without PSI support.
+func readPressure(procfsRoot string) (*PressureStat, error) {
+	stat := &PressureStat{}
+	for name, resource := range map[string]*PressureResource{
+		"cpu":    &stat.CPU,
+		"memory": &stat.Memory,
+		"io":     &stat.IO,
+	} {
+		data, err := os.ReadFile(filepath.Join(procfsRoot, "pressure", name))
+		if err != nil {
+			return nil, err
+		}
+		*resource = parsePressure(data)
+	}
+	return stat, nil
+}
+
+// parsePressure parses the contents of a PSI file such as
+// "some avg10=0.12 avg60=0.05 avg300=0.01 total=12345".
+func parsePressure(data []byte) PressureResource {
+	var resource PressureResource
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		fields := bytes.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var target *PressureLine
+		switch string(fields[0]) {
+		case "some":
+			target = &resource.Some
+		case "full":
+			target = &resource.Full
+		default:
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := bytes.Cut(field, []byte{'='})
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseFloat(string(value), 64)
+			if err != nil {
+				continue
+			}
+			switch string(key) {
+			case "avg10":
+				target.Avg10 = v
+			case "avg60":
+				target.Avg60 = v
+			case "avg300":
+				target.Avg300 = v
+			}
+		}
+	}
+	return resource
+}
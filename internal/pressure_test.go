@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePressure(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want PressureResource
+	}{
+		{
+			name: "some and full",
+			data: "some avg10=4.75 avg60=2.30 avg300=0.90 total=123456789\nfull avg10=2.10 avg60=1.05 avg300=0.40 total=65432109\n",
+			want: PressureResource{Some: PressureLine{4.75, 2.30, 0.90}, Full: PressureLine{2.10, 1.05, 0.40}},
+		},
+		{
+			// The cpu file has no full line before Linux 5.13.
+			name: "some only",
+			data: "some avg10=12.50 avg60=8.20 avg300=3.10 total=981234567\n",
+			want: PressureResource{Some: PressureLine{12.50, 8.20, 3.10}},
+		},
+		{
+			name: "malformed fields",
+			data: "some avg10=x avg60 avg300=1.5\nunknown avg10=9\n",
+			want: PressureResource{Some: PressureLine{Avg300: 1.5}},
+		},
+		{
+			name: "empty",
+			data: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePressure([]byte(tt.data)); got != tt.want {
+				t.Errorf("parsePressure = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPressure(t *testing.T) {
+	stat, err := readPressure(filepath.Join("testdata", "procfs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PressureStat{
+		CPU:    PressureResource{Some: PressureLine{12.50, 8.20, 3.10}},
+		Memory: PressureResource{Some: PressureLine{4.75, 2.30, 0.90}, Full: PressureLine{2.10, 1.05, 0.40}},
+		IO:     PressureResource{Some: PressureLine{31.20, 18.40, 6.70}, Full: PressureLine{22.80, 12.60, 4.30}},
+	}
+	if *stat != want {
+		t.Errorf("readPressure = %+v, want %+v", *stat, want)
+	}
+
+	// A cpu file without a full line, as on kernels before 5.13.
+	root := t.TempDir()
+	copyTree(t, filepath.Join("testdata", "procfs", "pressure"), filepath.Join(root, "pressure"))
+	if err := os.WriteFile(filepath.Join(root, "pressure", "cpu"), []byte("some avg10=1.00 avg60=0.50 avg300=0.25 total=42\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stat, err = readPressure(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (PressureResource{Some: PressureLine{1, 0.5, 0.25}}); stat.CPU != want {
+		t.Errorf("CPU pressure without a full line = %+v, want %+v", stat.CPU, want)
+	}
+
+	if _, err := readPressure(t.TempDir()); !os.IsNotExist(err) {
+		t.Errorf("error without PSI = %v, want a not exist error", err)
+	}
+}
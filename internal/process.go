@@ -1,12 +1,80 @@
 package internal
 
+import "time"
+
+// Process states as reported by the kernel in /proc/PID/stat.
+const (
+	StateRunning         = "R"
+	StateSleeping        = "S"
+	StateUninterruptible = "D"
+	StateZombie          = "Z"
+	StateStopped         = "T"
+	StateTraced          = "t"
+	StateIdle            = "I"
+)
+
 type ProcessInfo struct {
 	PID           int32
 	ParentPID     int32
 	Name          string
 	Username      string
+	State         string
+	NumThreads    int32
 	CPUPercent    float64
 	MemoryPercent float32
 	MemoryUsage   float64
 	RunningTime   string
+	// StartTime is when the process started, zero when unknown.
+	StartTime time.Time
+	// IOAvailable is false when the disk I/O counters could not be read,
+	// usually for processes of other users without privileges.
+	IOAvailable bool
+	ReadPerSec  float64 // bytes read from storage per second
+	WritePerSec float64 // bytes written to storage per second
+	// Cgroup is the cgroup v2 path of the process and Container the short
+	// name of the container it runs in, empty for host processes.
+	Cgroup    string
+	Container string
+}
+
+// ThreadInfo describes a single thread (task) of a process.
+type ThreadInfo struct {
+	TID        int32
+	Name       string
+	State      string
+	CPUPercent float64
+	// CPUMeasured is false when there is no previous sample of the thread
+	// to measure CPUPercent against, such as when the view was just opened.
+	CPUMeasured bool
+	LastCPU     int32
+}
+
+// TaskSummary counts processes by state, like the Tasks line of top.
+type TaskSummary struct {
+	Total           int
+	Running         int
+	Sleeping        int
+	Uninterruptible int
+	Stopped         int
+	Zombie          int
+}
+
+// summarizeTasks counts the given processes by state.
+func summarizeTasks(processInfos []ProcessInfo) TaskSummary {
+	summary := TaskSummary{Total: len(processInfos)}
+	for _, p := range processInfos {
+		switch p.State {
+		case StateRunning:
+			summary.Running++
+		case StateSleeping, StateIdle:
+			summary.Sleeping++
+		case StateUninterruptible:
+			summary.Uninterruptible++
+		case StateStopped, StateTraced:
+			summary.Stopped++
+		case StateZombie:
+			summary.Zombie++
+		}
+	}
+	return summary
 }
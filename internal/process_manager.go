@@ -1,6 +1,11 @@
 package internal
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"time"
 
@@ -14,38 +19,233 @@ const (
 	SortByMemory SortCriteria = "memory"
 	SortByPID    SortCriteria = "pid"
 	SortByName   SortCriteria = "name"
+	SortByIO     SortCriteria = "io"
 )
 
+// sortCriteriaOrder is the order in which the sort key cycles through the criteria.
+var sortCriteriaOrder = []SortCriteria{SortByCPU, SortByMemory, SortByIO, SortByPID, SortByName}
+
+// ErrNotSupported is returned for data that is not available on the current platform.
+var ErrNotSupported = errors.New("not supported on this platform")
+
 // ProcessOptions represents options for fetching processes.
 type ProcessOptions struct {
 	SortBy    SortCriteria
 	Limit     int
 	Ascending bool
+	// Include lists PIDs that are returned even when they fall beyond Limit.
+	Include []int32
+	// Pins select processes that lead the list in sort order and are
+	// returned in addition to the Limit others.
+	Pins []ProcessMatcher
+	// GroupBy selects how GetGroups aggregates processes. When Group is also
+	// set, GetProcesses only returns the members of the group with that key.
+	GroupBy GroupCriteria
+	Group   string
+	// Cached re-sorts and limits the processes of the previous scan instead
+	// of scanning again. Rescanning right after a refresh would measure CPU
+	// usage and I/O rates over a window of a few milliseconds.
+	Cached bool
 }
 
 // ProcessManager defines the interface for fetching and managing processes.
 type ProcessManager interface {
 	GetProcesses(opts ProcessOptions) ([]ProcessInfo, error)
+	// TaskSummary returns the process state counts of the last GetProcesses call,
+	// taken before the result was limited.
+	TaskSummary() TaskSummary
+	// GetThreads returns the threads of a process sorted by CPU usage.
+	GetThreads(pid int32) ([]ThreadInfo, error)
+	// GetOpenFiles returns the file descriptors of a process, with sockets resolved.
+	GetOpenFiles(pid int32) ([]OpenFile, error)
+	// GetListeningPorts returns the listening TCP and UDP sockets with their owning processes.
+	GetListeningPorts() ([]ListeningPort, error)
+	// GetGroups returns the processes aggregated by opts.GroupBy.
+	GetGroups(opts ProcessOptions) ([]ProcessGroup, error)
+}
+
+// DefaultProcessManager fetches processes through gopsutil.
+// It is used on platforms without a procfs.
+type DefaultProcessManager struct {
+	summary TaskSummary
+	// last is the result of the previous scan, reused for cached options.
+	last []ProcessInfo
+}
+
+// ProcfsProcessManager fetches processes by reading procfs directly.
+type ProcfsProcessManager struct {
+	root       string
+	cgroupRoot string
+	collector  *procfsCollector
+	summary    TaskSummary
+	// last is the result of the previous scan, reused for cached options.
+	last []ProcessInfo
+}
+
+// defaultProcfsRoot is where procfs is mounted on Linux.
+const defaultProcfsRoot = "/proc"
+
+// NewProcessManager returns a ProcfsProcessManager when a procfs is mounted at procfsRoot.
+// Without a procfs at the default root it falls back to the gopsutil based
+// DefaultProcessManager. Any other root without a procfs is an error, since
+// gopsutil would read the processes of the current namespace instead.
+func NewProcessManager(procfsRoot, sysfsRoot string) (ProcessManager, error) {
+	_, err := os.Stat(filepath.Join(procfsRoot, "stat"))
+	switch {
+	case err == nil:
+		return NewProcfsProcessManager(procfsRoot, sysfsRoot), nil
+	case filepath.Clean(procfsRoot) == defaultProcfsRoot:
+		return &DefaultProcessManager{}, nil
+	}
+	return nil, fmt.Errorf("no procfs at %s: %w", procfsRoot, err)
+}
+
+// NewProcfsProcessManager creates a ProcessManager reading from the procfs mounted at root.
+// Cgroup limits are read from the cgroup2 filesystem below sysfsRoot.
+func NewProcfsProcessManager(root, sysfsRoot string) *ProcfsProcessManager {
+	return &ProcfsProcessManager{
+		root:       root,
+		cgroupRoot: filepath.Join(sysfsRoot, "fs", "cgroup"),
+		collector:  newProcfsCollector(root),
+	}
+}
+
+func (m *ProcfsProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo, error) {
+	processInfos, err := m.scan(opts.Cached)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAndLimit(filterGroup(processInfos, opts), opts), nil
+}
+
+func (m *ProcfsProcessManager) GetGroups(opts ProcessOptions) ([]ProcessGroup, error) {
+	processInfos, err := m.scan(opts.Cached)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := sortAndLimitGroups(groupProcesses(processInfos, opts.GroupBy), opts)
+	if opts.GroupBy == GroupByCgroup {
+		for i := range groups {
+			if groups[i].Key != unknownGroup {
+				groups[i].Limits = readCgroupLimits(m.cgroupRoot, groups[i].Key)
+			}
+		}
+	}
+	return groups, nil
 }
 
-type DefaultProcessManager struct{}
+// scan reads all processes, or returns the previous scan when cached is set.
+func (m *ProcfsProcessManager) scan(cached bool) ([]ProcessInfo, error) {
+	if cached && m.last != nil {
+		return m.last, nil
+	}
+
+	processInfos, err := m.collector.collect()
+	if err != nil {
+		return nil, err
+	}
+	m.last = processInfos
+	m.summary = summarizeTasks(processInfos)
+	return processInfos, nil
+}
+
+func (m *ProcfsProcessManager) TaskSummary() TaskSummary {
+	return m.summary
+}
+
+func (m *ProcfsProcessManager) GetThreads(pid int32) ([]ThreadInfo, error) {
+	threads, err := m.collector.collectThreads(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].CPUPercent > threads[j].CPUPercent
+	})
+	return threads, nil
+}
+
+func (m *ProcfsProcessManager) GetOpenFiles(pid int32) ([]OpenFile, error) {
+	files, err := readOpenFiles(m.root, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].FD < files[j].FD
+	})
+	return files, nil
+}
 
-func NewProcessManager() ProcessManager {
-	return &DefaultProcessManager{}
+func (m *ProcfsProcessManager) GetListeningPorts() ([]ListeningPort, error) {
+	ports, err := readListeningPorts(m.root)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].LocalPort != ports[j].LocalPort {
+			return ports[i].LocalPort < ports[j].LocalPort
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports, nil
 }
 
 func (m *DefaultProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo, error) {
+	processInfos, err := m.scan(opts.Cached)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAndLimit(filterGroup(processInfos, opts), opts), nil
+}
+
+func (m *DefaultProcessManager) GetGroups(opts ProcessOptions) ([]ProcessGroup, error) {
+	processInfos, err := m.scan(opts.Cached)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAndLimitGroups(groupProcesses(processInfos, opts.GroupBy), opts), nil
+}
+
+// scan reads all processes, or returns the previous scan when cached is set.
+func (m *DefaultProcessManager) scan(cached bool) ([]ProcessInfo, error) {
+	if cached && m.last != nil {
+		return m.last, nil
+	}
+
+	processInfos, err := m.collect()
+	if err != nil {
+		return nil, err
+	}
+	m.last = processInfos
+	m.summary = summarizeTasks(processInfos)
+	return processInfos, nil
+}
+
+// collect reads all processes through gopsutil.
+func (m *DefaultProcessManager) collect() ([]ProcessInfo, error) {
 	procs, err := process.Processes()
 	if err != nil {
 		return nil, err
 	}
+	return readProcesses(procs), nil
+}
 
+// readProcesses reads the details of procs through gopsutil.
+func readProcesses(procs []*process.Process) []ProcessInfo {
 	var processInfos []ProcessInfo
 	for _, p := range procs {
 
 		parentPid := safeProcessInt32(p.Ppid)
 		name := safeProcessString(p.Name)
 		username := safeProcessString(p.Username)
+		state := safeProcessState(p)
+		numThreads := safeProcessInt32(p.NumThreads)
 		cpuPercent := safeProcessFloat64(p.CPUPercent)
 		memoryPercent := safeProcessFloat32(p.MemoryPercent)
 		createTime := safeProcessInt64(p.CreateTime)
@@ -57,8 +257,10 @@ func (m *DefaultProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo
 		}
 
 		runningTime := "Unknown"
+		var startTime time.Time
 		if createTime > 0 {
-			runningTime = time.Since(time.Unix(0, createTime*int64(time.Millisecond))).Truncate(time.Second).String()
+			startTime = time.UnixMilli(createTime)
+			runningTime = time.Since(startTime).Truncate(time.Second).String()
 		}
 
 		processInfos = append(processInfos, ProcessInfo{
@@ -66,13 +268,45 @@ func (m *DefaultProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo
 			ParentPID:     parentPid,
 			Name:          name,
 			Username:      username,
+			State:         state,
+			NumThreads:    numThreads,
 			CPUPercent:    cpuPercent,
 			MemoryPercent: memoryPercent,
 			MemoryUsage:   memoryUsage,
 			RunningTime:   runningTime,
+			StartTime:     startTime,
 		})
 	}
+	return processInfos
+}
+
+func (m *DefaultProcessManager) TaskSummary() TaskSummary {
+	return m.summary
+}
+
+func (m *DefaultProcessManager) GetThreads(pid int32) ([]ThreadInfo, error) {
+	return nil, ErrNotSupported
+}
+
+func (m *DefaultProcessManager) GetOpenFiles(pid int32) ([]OpenFile, error) {
+	return nil, ErrNotSupported
+}
+
+func (m *DefaultProcessManager) GetListeningPorts() ([]ListeningPort, error) {
+	return nil, ErrNotSupported
+}
 
+func DefaultProcessOptions() ProcessOptions {
+	return ProcessOptions{
+		SortBy:    SortByCPU,
+		Limit:     25,
+		Ascending: false,
+	}
+}
+
+// sortAndLimit sorts the processes according to opts and truncates them to opts.Limit.
+// Pinned processes are moved to the front and kept regardless of the limit.
+func sortAndLimit(processInfos []ProcessInfo, opts ProcessOptions) []ProcessInfo {
 	// Sort the process based on the SortBy Criteria in the options
 	// and the opts.Ascending to determine the sort direction
 	switch opts.SortBy {
@@ -97,6 +331,15 @@ func (m *DefaultProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo
 			}
 			return processInfos[i].MemoryUsage > processInfos[j].MemoryUsage
 		})
+	case SortByIO:
+		sort.Slice(processInfos, func(i, j int) bool {
+			ioI := processInfos[i].ReadPerSec + processInfos[i].WritePerSec
+			ioJ := processInfos[j].ReadPerSec + processInfos[j].WritePerSec
+			if opts.Ascending {
+				return ioI < ioJ
+			}
+			return ioI > ioJ
+		})
 	case SortByPID:
 		sort.Slice(processInfos, func(i, j int) bool {
 			if opts.Ascending {
@@ -106,17 +349,28 @@ func (m *DefaultProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo
 		})
 	}
 
-	if len(processInfos) > opts.Limit {
-		processInfos = processInfos[:opts.Limit]
+	var pinnedInfos []ProcessInfo
+	if len(opts.Pins) > 0 {
+		rest := make([]ProcessInfo, 0, len(processInfos))
+		for _, p := range processInfos {
+			if pinned(opts.Pins, p) {
+				pinnedInfos = append(pinnedInfos, p)
+			} else {
+				rest = append(rest, p)
+			}
+		}
+		processInfos = rest
 	}
 
-	return processInfos, nil
-}
-
-func DefaultProcessOptions() ProcessOptions {
-	return ProcessOptions{
-		SortBy:    SortByCPU,
-		Limit:     25,
-		Ascending: false,
+	if len(processInfos) > opts.Limit {
+		limited := processInfos[:opts.Limit:opts.Limit]
+		for _, p := range processInfos[opts.Limit:] {
+			if slices.Contains(opts.Include, p.PID) {
+				limited = append(limited, p)
+			}
+		}
+		processInfos = limited
 	}
+
+	return append(pinnedInfos, processInfos...)
 }
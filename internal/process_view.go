@@ -1,7 +1,13 @@
 package internal
 
 import (
-	"github.com/charmbracelet/bubbles/table"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -17,7 +23,81 @@ func NewProcessView(viewStyle lipgloss.Style) *ProcessView {
 	}
 }
 
-// Render renders the process table.
-func (p *ProcessView) Render(processTable table.Model) string {
-	return p.viewStyle.Render(processTable.View())
+// Render renders the process table, preceded by a title line in sub-views.
+func (p *ProcessView) Render(m Model) string {
+	if title := p.title(m); title != "" {
+		return p.viewStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Padding(0, 1).Render(title),
+			m.processTable.View(),
+		))
+	}
+	return p.viewStyle.Render(m.processTable.View())
+}
+
+// title returns the title line for the current view mode.
+// Key hints are left to the help bar, which follows remapped bindings.
+func (p *ProcessView) title(m Model) string {
+	var title string
+	switch m.mode {
+	case modeThreads:
+		title = fmt.Sprintf("Threads of %s (PID %d)", m.detailName, m.detailPID)
+		if len(m.threads) > 0 && !slices.ContainsFunc(m.threads, func(t ThreadInfo) bool { return t.CPUMeasured }) {
+			title += " · CPU% from the next refresh"
+		}
+	case modeOpenFiles:
+		title = fmt.Sprintf("Open files of %s (PID %d)", m.detailName, m.detailPID)
+	case modePorts:
+		title = "Listening ports"
+	case modeGroups:
+		return fmt.Sprintf("Processes by %s", m.processOptions.GroupBy)
+	default:
+		if m.processOptions.Group == "" {
+			return ""
+		}
+		title = "Processes in " + m.groupName
+	}
+
+	if errors.Is(m.detailErr, os.ErrPermission) {
+		title += " · permission denied"
+	} else if m.detailErr != nil {
+		title += " · " + m.detailErr.Error()
+	}
+	return title
+}
+
+// stateStyle returns the style function for the process state column.
+// Running, uninterruptible, stopped and zombie processes are color-coded.
+func stateStyle(colors ColorConfig) func(string) lipgloss.Style {
+	return func(state string) lipgloss.Style {
+		style := lipgloss.NewStyle()
+		switch state {
+		case StateRunning:
+			return style.Foreground(colors.StateRunning)
+		case StateUninterruptible:
+			return style.Foreground(colors.StateUninterruptible).Bold(true)
+		case StateStopped, StateTraced:
+			return style.Foreground(colors.StateStopped)
+		case StateZombie:
+			return style.Foreground(colors.StateZombie).Bold(true)
+		}
+		return style
+	}
+}
+
+// thresholdStyle returns a style function for numeric cells such as "93%",
+// coloring values at or above high and critical with the warning and critical colors.
+func thresholdStyle(colors ColorConfig, high, critical float64) func(string) lipgloss.Style {
+	return func(value string) lipgloss.Style {
+		style := lipgloss.NewStyle()
+		v, err := strconv.ParseFloat(strings.TrimRight(value, "%°C"), 64)
+		switch {
+		case err != nil:
+			return style
+		case v >= critical:
+			return style.Foreground(colors.Critical).Bold(true)
+		case v >= high:
+			return style.Foreground(colors.Warning)
+		}
+		return style
+	}
 }
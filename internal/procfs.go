@@ -0,0 +1,470 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// userHZ is the tick rate the kernel uses for the time fields in /proc/PID/stat.
+// It is fixed at 100 on every architecture Linux exports to userspace.
+const userHZ = 100
+
+// maxProcfsWorkers bounds the number of goroutines reading /proc concurrently.
+const maxProcfsWorkers = 8
+
+// procSample holds the raw values read for a single process during one scan.
+type procSample struct {
+	PID        int32
+	PPID       int32
+	Name       string
+	State      string
+	CPUTicks   uint64 // utime + stime, in clock ticks
+	StartTicks uint64 // start time after boot, in clock ticks
+	NumThreads int32
+	Processor  int32  // CPU the task last ran on
+	RSS        uint64 // resident set size in bytes
+	UID        uint32
+	HasIO      bool // false when /proc/PID/io could not be read
+	ReadBytes  uint64
+	WriteBytes uint64
+	Cgroup     string
+}
+
+// procfsCollector reads process information straight from a procfs tree.
+// Each PID costs one read of stat, statm, status, io and cgroup, and the previous scan
+// is kept so CPU usage and I/O rates can be computed from deltas.
+type procfsCollector struct {
+	root     string
+	workers  int
+	pageSize uint64
+	users    *userCache
+
+	bufPool sync.Pool
+
+	mu       sync.Mutex
+	bootTime time.Time
+	prev     map[int32]*procSample
+	prevScan time.Time
+
+	threadPID      int32
+	prevThreadCPU  map[int32]uint64
+	prevThreadScan time.Time
+}
+
+func newProcfsCollector(root string) *procfsCollector {
+	workers := runtime.NumCPU()
+	if workers > maxProcfsWorkers {
+		workers = maxProcfsWorkers
+	}
+
+	return &procfsCollector{
+		root:     root,
+		workers:  workers,
+		pageSize: uint64(os.Getpagesize()),
+		users:    newUserCache(),
+		bufPool: sync.Pool{
+			New: func() any {
+				buf := make([]byte, 0, 4096)
+				return &buf
+			},
+		},
+		prev: make(map[int32]*procSample),
+	}
+}
+
+// collect scans every PID under the procfs root and converts the samples into ProcessInfo values.
+func (c *procfsCollector) collect() ([]ProcessInfo, error) {
+	pids, err := c.listPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	memTotal, err := c.memTotal()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.bootTime.IsZero() {
+		if c.bootTime, err = c.readBootTime(); err != nil {
+			return nil, err
+		}
+	}
+
+	samples := c.scan(pids)
+	now := time.Now()
+	elapsed := now.Sub(c.prevScan).Seconds()
+
+	containers := make(map[string]string)
+	curr := make(map[int32]*procSample, len(samples))
+	processInfos := make([]ProcessInfo, 0, len(samples))
+	for _, s := range samples {
+		if s == nil {
+			continue
+		}
+		curr[s.PID] = s
+
+		started := c.bootTime.Add(time.Duration(s.StartTicks) * time.Second / userHZ)
+		prev, seen := c.prev[s.PID]
+		// A recycled PID shows up with a different start time.
+		seen = seen && prev.StartTicks == s.StartTicks && elapsed > 0
+
+		var cpuPercent float64
+		if seen && s.CPUTicks >= prev.CPUTicks {
+			cpuPercent = float64(s.CPUTicks-prev.CPUTicks) / userHZ / elapsed * 100
+		} else if lifetime := now.Sub(started).Seconds(); lifetime > 0 {
+			// First time we see this process, so fall back to the lifetime average.
+			cpuPercent = float64(s.CPUTicks) / userHZ / lifetime * 100
+		}
+
+		var memoryPercent float32
+		if memTotal > 0 {
+			memoryPercent = float32(float64(s.RSS) / float64(memTotal) * 100)
+		}
+
+		container, ok := containers[s.Cgroup]
+		if !ok {
+			container = containerName(s.Cgroup)
+			containers[s.Cgroup] = container
+		}
+
+		var readRate, writeRate float64
+		if seen && s.HasIO && prev.HasIO {
+			readRate = float64(s.ReadBytes-min(prev.ReadBytes, s.ReadBytes)) / elapsed
+			writeRate = float64(s.WriteBytes-min(prev.WriteBytes, s.WriteBytes)) / elapsed
+		}
+
+		processInfos = append(processInfos, ProcessInfo{
+			PID:           s.PID,
+			ParentPID:     s.PPID,
+			Name:          s.Name,
+			Username:      c.users.lookup(s.UID),
+			State:         s.State,
+			NumThreads:    s.NumThreads,
+			CPUPercent:    cpuPercent,
+			MemoryPercent: memoryPercent,
+			MemoryUsage:   float64(s.RSS) / (1024 * 1024), // Convert bytes to MB
+			RunningTime:   now.Sub(started).Truncate(time.Second).String(),
+			StartTime:     started,
+			IOAvailable:   s.HasIO,
+			ReadPerSec:    readRate,
+			WritePerSec:   writeRate,
+			Cgroup:        s.Cgroup,
+			Container:     container,
+		})
+	}
+
+	c.prev = curr
+	c.prevScan = now
+
+	return processInfos, nil
+}
+
+// collectThreads reads every task of pid. CPU usage is computed from the
+// deltas against the previous call for the same pid.
+func (c *procfsCollector) collectThreads(pid int32) ([]ThreadInfo, error) {
+	taskDir := filepath.Join(c.root, strconv.Itoa(int(pid)), "task")
+	entries, err := os.ReadDir(taskDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.threadPID != pid {
+		c.threadPID = pid
+		c.prevThreadCPU = make(map[int32]uint64)
+	}
+
+	bufp := c.bufPool.Get().(*[]byte)
+	defer c.bufPool.Put(bufp)
+
+	now := time.Now()
+	elapsed := now.Sub(c.prevThreadScan).Seconds()
+
+	currCPU := make(map[int32]uint64, len(entries))
+	threads := make([]ThreadInfo, 0, len(entries))
+	for _, entry := range entries {
+		tid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		s := &procSample{PID: int32(tid)}
+		data, err := readFileInto(filepath.Join(taskDir, entry.Name(), "stat"), bufp)
+		if err != nil || parseStat(data, s) != nil {
+			continue
+		}
+		currCPU[s.PID] = s.CPUTicks
+
+		var cpuPercent float64
+		prev, measured := c.prevThreadCPU[s.PID]
+		measured = measured && elapsed > 0 && s.CPUTicks >= prev
+		if measured {
+			cpuPercent = float64(s.CPUTicks-prev) / userHZ / elapsed * 100
+		}
+
+		threads = append(threads, ThreadInfo{
+			TID:         s.PID,
+			Name:        s.Name,
+			State:       s.State,
+			CPUPercent:  cpuPercent,
+			CPUMeasured: measured,
+			LastCPU:     s.Processor,
+		})
+	}
+
+	c.prevThreadCPU = currCPU
+	c.prevThreadScan = now
+
+	return threads, nil
+}
+
+// scan reads all PIDs using a bounded pool of workers.
+// The result has one slot per PID, left nil when the process vanished mid-scan.
+func (c *procfsCollector) scan(pids []int32) []*procSample {
+	samples := make([]*procSample, len(pids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range c.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bufp := c.bufPool.Get().(*[]byte)
+			defer c.bufPool.Put(bufp)
+
+			for i := range jobs {
+				samples[i] = c.readProcess(pids[i], bufp)
+			}
+		}()
+	}
+
+	for i := range pids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return samples
+}
+
+// listPIDs returns the numeric entries of the procfs root.
+func (c *procfsCollector) listPIDs() ([]int32, error) {
+	dir, err := os.Open(c.root)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	pids := make([]int32, 0, len(names))
+	for _, name := range names {
+		pid, err := strconv.ParseInt(name, 10, 32)
+		if err != nil {
+			continue
+		}
+		pids = append(pids, int32(pid))
+	}
+	return pids, nil
+}
+
+// readProcess reads stat, statm, status, io and cgroup for a single PID.
+// The io file is only readable for our own processes without privileges,
+// so failing to read it leaves HasIO unset instead of dropping the process.
+func (c *procfsCollector) readProcess(pid int32, bufp *[]byte) *procSample {
+	dir := filepath.Join(c.root, strconv.Itoa(int(pid)))
+	s := &procSample{PID: pid}
+
+	data, err := readFileInto(filepath.Join(dir, "stat"), bufp)
+	if err != nil || parseStat(data, s) != nil {
+		return nil
+	}
+
+	data, err = readFileInto(filepath.Join(dir, "statm"), bufp)
+	if err != nil {
+		return nil
+	}
+	if resident, ok := fieldUint(data, 1); ok {
+		s.RSS = resident * c.pageSize
+	}
+
+	data, err = readFileInto(filepath.Join(dir, "status"), bufp)
+	if err != nil {
+		return nil
+	}
+	if uid, ok := statusField(data, "Uid:"); ok {
+		if v, ok := fieldUint(uid, 0); ok {
+			s.UID = uint32(v)
+		}
+	}
+
+	if data, err = readFileInto(filepath.Join(dir, "io"), bufp); err == nil {
+		readBytes, okRead := statusField(data, "read_bytes:")
+		writeBytes, okWrite := statusField(data, "write_bytes:")
+		if okRead && okWrite {
+			s.ReadBytes, _ = fieldUint(readBytes, 0)
+			s.WriteBytes, _ = fieldUint(writeBytes, 0)
+			s.HasIO = true
+		}
+	}
+
+	if data, err = readFileInto(filepath.Join(dir, "cgroup"), bufp); err == nil {
+		s.Cgroup = parseCgroupFile(data)
+	}
+
+	return s
+}
+
+// parseStat parses the contents of /proc/PID/stat into s.
+// The command name may contain spaces and parentheses, so it is taken
+// from between the first '(' and the last ')'.
+func parseStat(data []byte, s *procSample) error {
+	open := bytes.IndexByte(data, '(')
+	end := bytes.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return errors.New("malformed stat")
+	}
+	s.Name = string(data[open+1 : end])
+
+	// Fields after the command name start at field 3 (state) of proc(5).
+	fields := bytes.Fields(data[end+1:])
+	if len(fields) < 20 {
+		return errors.New("short stat")
+	}
+	field := func(n int) uint64 {
+		v, _ := strconv.ParseUint(string(fields[n-3]), 10, 64)
+		return v
+	}
+
+	s.State = string(fields[0])
+	s.PPID = int32(field(4))
+	s.CPUTicks = field(14) + field(15)
+	s.NumThreads = int32(field(20))
+	s.StartTicks = field(22)
+	if len(fields) > 39-3 {
+		s.Processor = int32(field(39))
+	}
+	return nil
+}
+
+// memTotal returns MemTotal from meminfo in bytes.
+func (c *procfsCollector) memTotal() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(c.root, "meminfo"))
+	if err != nil {
+		return 0, err
+	}
+	value, ok := statusField(data, "MemTotal:")
+	if !ok {
+		return 0, errors.New("MemTotal not found in meminfo")
+	}
+	kb, _ := fieldUint(value, 0)
+	return kb * 1024, nil
+}
+
+// readBootTime returns the btime entry of the procfs stat file.
+func (c *procfsCollector) readBootTime() (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(c.root, "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	value, ok := statusField(data, "btime")
+	if !ok {
+		return time.Time{}, errors.New("btime not found in stat")
+	}
+	secs, _ := fieldUint(value, 0)
+	return time.Unix(int64(secs), 0), nil
+}
+
+// readFileInto reads the whole file into the buffer behind bufp, growing it when needed.
+func readFileInto(path string, bufp *[]byte) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := (*bufp)[:0]
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := f.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err != nil {
+			*bufp = buf
+			if errors.Is(err, io.EOF) {
+				return buf, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// statusField returns the remainder of the first line starting with key.
+func statusField(data []byte, key string) ([]byte, bool) {
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		if bytes.HasPrefix(line, []byte(key)) {
+			return line[len(key):], true
+		}
+	}
+	return nil, false
+}
+
+// fieldUint parses the n-th whitespace separated field of data as an unsigned integer.
+func fieldUint(data []byte, n int) (uint64, bool) {
+	fields := bytes.Fields(data)
+	if n >= len(fields) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(fields[n]), 10, 64)
+	return v, err == nil
+}
+
+// userCache resolves UIDs to usernames, remembering every answer.
+type userCache struct {
+	mu    sync.Mutex
+	names map[uint32]string
+}
+
+func newUserCache() *userCache {
+	return &userCache{names: make(map[uint32]string)}
+}
+
+func (u *userCache) lookup(uid uint32) string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if name, ok := u.names[uid]; ok {
+		return name
+	}
+
+	id := strconv.FormatUint(uint64(uid), 10)
+	name := id
+	if usr, err := user.LookupId(id); err == nil {
+		name = usr.Username
+	}
+	u.names[uid] = name
+	return name
+}
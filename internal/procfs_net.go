@@ -0,0 +1,332 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tcpStates maps the hex state codes of /proc/net/tcp to their names.
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+// unixListening is the __SO_ACCEPTCON flag set on listening unix sockets.
+const unixListening = 0x10000
+
+// readSocketTables reads the tcp, tcp6, udp, udp6 and unix tables in netDir
+// and indexes the sockets by inode. Missing tables, e.g. without IPv6, are skipped.
+func readSocketTables(netDir string) (map[uint64]Socket, error) {
+	sockets := make(map[uint64]Socket)
+	found := false
+
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		err := readInetTable(filepath.Join(netDir, proto), proto, sockets)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+	}
+
+	err := readUnixTable(filepath.Join(netDir, "unix"), sockets)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err != nil && !found {
+		return nil, err
+	}
+
+	return sockets, nil
+}
+
+// readInetTable parses a /proc/net/{tcp,tcp6,udp,udp6} table.
+func readInetTable(path, proto string, sockets map[uint64]Socket) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // skip the header line
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		localIP, localPort, err := parseHexAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		remoteIP, remotePort, err := parseHexAddr(fields[2])
+		if err != nil {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		state := tcpStates[fields[3]]
+		if strings.HasPrefix(proto, "udp") {
+			// UDP sockets report TCP_CLOSE when unconnected and are
+			// the equivalent of a listening socket in that state.
+			switch fields[3] {
+			case "07":
+				state = "UNCONN"
+			case "01":
+				state = "ESTABLISHED"
+			}
+		}
+
+		sockets[inode] = Socket{
+			Protocol:   proto,
+			LocalIP:    localIP,
+			LocalPort:  localPort,
+			RemoteIP:   remoteIP,
+			RemotePort: remotePort,
+			State:      state,
+			Inode:      inode,
+		}
+	}
+	return scanner.Err()
+}
+
+// readUnixTable parses /proc/net/unix.
+func readUnixTable(path string, sockets map[uint64]Socket) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // skip the header line
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			continue
+		}
+		flags, _ := strconv.ParseUint(fields[3], 16, 64)
+
+		state := "UNCONNECTED"
+		switch {
+		case flags&unixListening != 0:
+			state = "LISTEN"
+		case fields[5] == "03":
+			state = "CONNECTED"
+		}
+
+		path := ""
+		if len(fields) > 7 {
+			path = fields[7]
+		}
+
+		sockets[inode] = Socket{
+			Protocol: "unix",
+			Path:     path,
+			State:    state,
+			Inode:    inode,
+		}
+	}
+	return scanner.Err()
+}
+
+// parseHexAddr parses an "ADDR:PORT" pair as found in /proc/net/tcp.
+// Addresses are stored as 32-bit words in host byte order.
+func parseHexAddr(s string) (string, uint16, error) {
+	addr, port, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, errors.New("malformed address")
+	}
+
+	raw, err := hex.DecodeString(addr)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, errors.New("malformed address")
+	}
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(raw[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+
+	p, err := strconv.ParseUint(port, 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+	return net.IP(raw).String(), uint16(p), nil
+}
+
+// readOpenFiles lists the file descriptors of pid and resolves sockets against
+// the socket tables of the process's network namespace. Descriptors that
+// cannot be resolved are kept with a "-" target, marked as denied when that is
+// for lack of permissions, and descriptors closed while listing are skipped.
+func readOpenFiles(procRoot string, pid int32) ([]OpenFile, error) {
+	pidDir := filepath.Join(procRoot, strconv.Itoa(int(pid)))
+	fdDir := filepath.Join(pidDir, "fd")
+
+	// ReadDir returns the entries read before an error, which are still shown.
+	entries, err := os.ReadDir(fdDir)
+	if err != nil && len(entries) == 0 {
+		return nil, err
+	}
+
+	sockets, err := readSocketTables(filepath.Join(pidDir, "net"))
+	if err != nil {
+		sockets = map[uint64]Socket{}
+	}
+
+	files := make([]OpenFile, 0, len(entries))
+	for _, entry := range entries {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			files = append(files, OpenFile{FD: fd, Type: FileTypeUnknown, Target: "-", Denied: errors.Is(err, os.ErrPermission)})
+			continue
+		}
+
+		file := OpenFile{FD: fd, Type: fileType(target), Target: target}
+		if file.Type == FileTypeSocket {
+			if inode, ok := linkInode(target); ok {
+				if socket, ok := sockets[inode]; ok {
+					file.Socket = &socket
+				}
+			}
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// fileType classifies the target of a /proc/PID/fd link.
+func fileType(target string) string {
+	switch {
+	case strings.HasPrefix(target, "socket:"):
+		return FileTypeSocket
+	case strings.HasPrefix(target, "pipe:"):
+		return FileTypePipe
+	case strings.HasPrefix(target, "anon_inode:"):
+		return FileTypeAnonInode
+	case strings.HasPrefix(target, "/dev/"):
+		return FileTypeDevice
+	case strings.HasPrefix(target, "/"):
+		return FileTypeFile
+	default:
+		return FileTypeUnknown
+	}
+}
+
+// linkInode extracts the inode from a "socket:[12345]" style link target.
+func linkInode(target string) (uint64, bool) {
+	start := strings.IndexByte(target, '[')
+	end := strings.LastIndexByte(target, ']')
+	if start < 0 || end < start {
+		return 0, false
+	}
+	inode, err := strconv.ParseUint(target[start+1:end], 10, 64)
+	return inode, err == nil
+}
+
+// readListeningPorts returns the listening TCP and UDP sockets of the network
+// namespace procRoot belongs to, with their owners found by matching socket
+// inodes against every process's file descriptors. Sockets whose owner cannot
+// be determined, e.g. without privileges, are kept with PID 0.
+func readListeningPorts(procRoot string) ([]ListeningPort, error) {
+	sockets, err := readSocketTables(filepath.Join(procRoot, "net"))
+	if err != nil {
+		return nil, err
+	}
+
+	listening := make(map[uint64]Socket)
+	for inode, socket := range sockets {
+		if socket.Protocol != "unix" && (socket.State == "LISTEN" || socket.State == "UNCONN") {
+			listening[inode] = socket
+		}
+	}
+
+	owners := socketOwners(procRoot, listening)
+
+	ports := make([]ListeningPort, 0, len(listening))
+	for inode, socket := range listening {
+		port := ListeningPort{Socket: socket, Name: "-"}
+		if pid, ok := owners[inode]; ok {
+			port.PID = pid
+			if comm, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(int(pid)), "comm")); err == nil {
+				port.Name = strings.TrimSpace(string(comm))
+			}
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// socketOwners maps the inodes of the given sockets to the PID holding them.
+func socketOwners(procRoot string, sockets map[uint64]Socket) map[uint64]int32 {
+	owners := make(map[uint64]int32, len(sockets))
+
+	dir, err := os.Open(procRoot)
+	if err != nil {
+		return owners
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return owners
+	}
+
+	for _, name := range names {
+		pid, err := strconv.ParseInt(name, 10, 32)
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(procRoot, name, "fd")
+		entries, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+			if err != nil || !strings.HasPrefix(target, "socket:") {
+				continue
+			}
+			inode, ok := linkInode(target)
+			if _, listening := sockets[inode]; ok && listening {
+				if _, taken := owners[inode]; !taken {
+					owners[inode] = int32(pid)
+				}
+			}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadOpenFiles(t *testing.T) {
+	root := t.TempDir()
+	copyTree(t, filepath.Join("testdata", "procfs"), root)
+	// A descriptor that cannot be read as a link.
+	if err := os.WriteFile(filepath.Join(root, "42", "fd", "9"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := NewProcfsProcessManager(root, t.TempDir()).GetOpenFiles(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fd         int
+		fileType   string
+		name       string
+		remoteAddr string
+	}{
+		{0, FileTypeDevice, "/dev/null", ""},
+		{1, FileTypeFile, "/var/log/app.log", ""},
+		{3, FileTypeSocket, "127.0.0.1:8080", "10.0.0.2:51234"},
+		{4, FileTypePipe, "pipe:[4242]", ""},
+		{5, FileTypeAnonInode, "anon_inode:[eventfd]", ""},
+		{6, FileTypeSocket, "/run/app.sock", ""},
+		{9, FileTypeUnknown, "-", ""},
+	}
+	if len(files) != len(tests) {
+		t.Fatalf("got %d open files, want %d: %+v", len(files), len(tests), files)
+	}
+	for i, tt := range tests {
+		f := files[i]
+		remoteAddr := ""
+		if f.Socket != nil {
+			remoteAddr = f.Socket.RemoteAddr()
+		}
+		if f.FD != tt.fd || f.Type != tt.fileType || f.Name() != tt.name || remoteAddr != tt.remoteAddr {
+			t.Errorf("file %d = fd %d, type %s, name %q, remote %q, want fd %d, type %s, name %q, remote %q",
+				i, f.FD, f.Type, f.Name(), remoteAddr, tt.fd, tt.fileType, tt.name, tt.remoteAddr)
+		}
+	}
+	if s := files[2].Socket; s == nil || s.Protocol != "tcp" || s.State != "ESTABLISHED" {
+		t.Errorf("socket of fd 3 = %+v, want an established tcp socket", s)
+	}
+	if s := files[5].Socket; s == nil || s.Protocol != "unix" || s.State != "CONNECTED" {
+		t.Errorf("socket of fd 6 = %+v, want a connected unix socket", s)
+	}
+}
+
+func TestReadOpenFilesMissingProcess(t *testing.T) {
+	if _, err := readOpenFiles(filepath.Join("testdata", "procfs"), 999); !os.IsNotExist(err) {
+		t.Errorf("error = %v, want a not exist error", err)
+	}
+}
+
+func TestParseHexAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		wantIP   string
+		wantPort uint16
+		wantErr  bool
+	}{
+		{"0100007F:1F90", "127.0.0.1", 8080, false},
+		{"00000000:0016", "0.0.0.0", 22, false},
+		{"00000000000000000000000001000000:0050", "::1", 80, false},
+		{"0000000000000000FFFF00000100007F:1F91", "127.0.0.1", 8081, false},
+		{"B80D01200000000000000000010000C0:01BB", "2001:db8::c000:1", 443, false},
+		{"0100007F", "", 0, true},
+		{"0100007:1F90", "", 0, true},
+		{"0100007F00:1F90", "", 0, true},
+		{"0100007F:XYZ", "", 0, true},
+	}
+	for _, tt := range tests {
+		ip, port, err := parseHexAddr(tt.addr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHexAddr(%q) error = %v, want error %t", tt.addr, err, tt.wantErr)
+			continue
+		}
+		if ip != tt.wantIP || port != tt.wantPort {
+			t.Errorf("parseHexAddr(%q) = %s, %d, want %s, %d", tt.addr, ip, port, tt.wantIP, tt.wantPort)
+		}
+	}
+}
+
+func TestReadSocketTables(t *testing.T) {
+	sockets, err := readSocketTables(filepath.Join("testdata", "procfs", "net"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		inode uint64
+		want  Socket
+	}{
+		{5001, Socket{Protocol: "tcp", LocalIP: "127.0.0.1", LocalPort: 8080, RemoteIP: "0.0.0.0", State: "LISTEN", Inode: 5001}},
+		{5003, Socket{Protocol: "tcp", LocalIP: "127.0.0.1", LocalPort: 8080, RemoteIP: "10.0.0.2", RemotePort: 51234, State: "ESTABLISHED", Inode: 5003}},
+		{5004, Socket{Protocol: "tcp6", LocalIP: "::1", LocalPort: 80, RemoteIP: "::", State: "LISTEN", Inode: 5004}},
+		{5005, Socket{Protocol: "tcp6", LocalIP: "127.0.0.1", LocalPort: 8081, RemoteIP: "::", State: "LISTEN", Inode: 5005}},
+		{5006, Socket{Protocol: "udp", LocalIP: "0.0.0.0", LocalPort: 53, RemoteIP: "0.0.0.0", State: "UNCONN", Inode: 5006}},
+	}
+	if len(sockets) != 6 {
+		t.Errorf("got %d sockets, want 6", len(sockets))
+	}
+	for _, tt := range tests {
+		if got := sockets[tt.inode]; got != tt.want {
+			t.Errorf("socket %d = %+v, want %+v", tt.inode, got, tt.want)
+		}
+	}
+}
+
+func TestReadListeningPorts(t *testing.T) {
+	ports, err := NewProcfsProcessManager(filepath.Join("testdata", "procfs"), t.TempDir()).GetListeningPorts()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The established socket on 8080 is not listed, and the ::1 listener
+	// has no owner among the fixture processes.
+	tests := []struct {
+		protocol string
+		port     uint16
+		pid      int32
+		name     string
+	}{
+		{"tcp", 22, 1, "systemd"},
+		{"udp", 53, 1, "systemd"},
+		{"tcp6", 80, 0, "-"},
+		{"tcp", 8080, 100, "tmux: server"},
+		{"tcp6", 8081, 100, "tmux: server"},
+	}
+	if len(ports) != len(tests) {
+		t.Fatalf("got %d listening ports, want %d: %+v", len(ports), len(tests), ports)
+	}
+	for i, tt := range tests {
+		p := ports[i]
+		if p.Protocol != tt.protocol || p.LocalPort != tt.port || p.PID != tt.pid || p.Name != tt.name {
+			t.Errorf("port %d = %s/%d by %d %q, want %s/%d by %d %q",
+				i, p.Protocol, p.LocalPort, p.PID, p.Name, tt.protocol, tt.port, tt.pid, tt.name)
+		}
+	}
+}
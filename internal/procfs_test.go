@@ -0,0 +1,323 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+func TestParseStat(t *testing.T) {
+	tests := []struct {
+		name    string
+		stat    string
+		want    procSample
+		wantErr bool
+	}{
+		{
+			name: "parentheses in name",
+			stat: "42 (my proc (x)) R 1 42 42 0 -1 4194560 1000 0 10 0 90000 4000 0 0 20 0 4 0 50000 1024000000 25000 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0",
+			want: procSample{Name: "my proc (x)", State: "R", PPID: 1, CPUTicks: 94000, NumThreads: 4, StartTicks: 50000, Processor: 3},
+		},
+		{
+			name: "space and colon in name",
+			stat: "100 (tmux: server) S 1 100 100 0 -1 4194560 1000 0 10 0 3000 1000 0 0 20 0 1 0 8000 49152000 1200 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 0 0 0",
+			want: procSample{Name: "tmux: server", State: "S", PPID: 1, CPUTicks: 4000, NumThreads: 1, StartTicks: 8000},
+		},
+		{
+			name: "zombie",
+			stat: "77 (defunct) Z 42 77 77 0 -1 4194560 1000 0 10 0 10 5 0 0 20 0 1 0 60000 0 0 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 0 0 0",
+			want: procSample{Name: "defunct", State: "Z", PPID: 42, CPUTicks: 15, NumThreads: 1, StartTicks: 60000},
+		},
+		{
+			name: "without processor field",
+			stat: "9 (short) S 1 9 9 0 -1 4194560 1000 0 10 0 7 3 0 0 20 0 2 0 900",
+			want: procSample{Name: "short", State: "S", PPID: 1, CPUTicks: 10, NumThreads: 2, StartTicks: 900},
+		},
+		{
+			name:    "truncated",
+			stat:    "9 (short) S 1 9 9",
+			wantErr: true,
+		},
+		{
+			name:    "no closing parenthesis",
+			stat:    "9 (broken S 1 9 9 0 -1 4194560 1000 0 10 0 7 3 0 0 20 0 2 0 900",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			stat:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got procSample
+			err := parseStat([]byte(tt.stat), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseStat(%q) succeeded, want an error", tt.stat)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStat(%q): %v", tt.stat, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseStat(%q) = %+v, want %+v", tt.stat, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcfsProcessManagerFixtures(t *testing.T) {
+	manager := NewProcfsProcessManager(filepath.Join("testdata", "procfs"), filepath.Join("testdata", "sysfs"))
+
+	processes, err := manager.GetProcesses(ProcessOptions{SortBy: SortByPID, Ascending: true, Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type process struct {
+		PID       int32
+		Name      string
+		State     string
+		ParentPID int32
+	}
+	want := []process{
+		{1, "systemd", StateSleeping, 0},
+		{42, "my proc (x)", StateRunning, 1},
+		{77, "defunct", StateZombie, 42},
+		{100, "tmux: server", StateSleeping, 1},
+		{200, "kworker/0:1", StateIdle, 2},
+		{300, "fsck", StateUninterruptible, 1},
+		{400, "stopped-job", StateStopped, 100},
+	}
+	if len(processes) != len(want) {
+		t.Fatalf("got %d processes, want %d", len(processes), len(want))
+	}
+	for i, p := range processes {
+		if got := (process{p.PID, p.Name, p.State, p.ParentPID}); got != want[i] {
+			t.Errorf("process %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	wantSummary := TaskSummary{Total: 7, Running: 1, Sleeping: 3, Uninterruptible: 1, Stopped: 1, Zombie: 1}
+	if got := manager.TaskSummary(); got != wantSummary {
+		t.Errorf("TaskSummary() = %+v, want %+v", got, wantSummary)
+	}
+}
+
+// copyTree copies the directory tree at src to dst.
+func copyTree(tb testing.TB, src, dst string) {
+	tb.Helper()
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestProcfsProcessManagerThreads(t *testing.T) {
+	root := t.TempDir()
+	copyTree(t, filepath.Join("testdata", "procfs"), root)
+	manager := NewProcfsProcessManager(root, t.TempDir())
+
+	threads, err := manager.GetThreads(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(threads, func(a, b ThreadInfo) int { return int(a.TID - b.TID) })
+
+	want := []ThreadInfo{
+		{TID: 42, Name: "my proc (x)", State: StateSleeping, LastCPU: 0},
+		{TID: 1042, Name: "worker-1", State: StateRunning, LastCPU: 1},
+		{TID: 2042, Name: "worker-2", State: StateSleeping, LastCPU: 0},
+		{TID: 3042, Name: "gc", State: StateSleeping, LastCPU: 1},
+	}
+	// The first call has no previous sample to measure CPU usage against.
+	if !slices.Equal(threads, want) {
+		t.Fatalf("GetThreads(42) =\n%+v\nwant\n%+v", threads, want)
+	}
+
+	// worker-1 used 50 ticks, half a second of CPU time, over one second.
+	stat := filepath.Join(root, "42", "task", "1042", "stat")
+	data, err := os.ReadFile(stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), " 80000 4000 ", " 80050 4000 ", 1))
+	if err := os.WriteFile(stat, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager.collector.prevThreadScan = manager.collector.prevThreadScan.Add(-time.Second)
+
+	if threads, err = manager.GetThreads(42); err != nil {
+		t.Fatal(err)
+	}
+	if threads[0].TID != 1042 || threads[0].CPUPercent < 49 || threads[0].CPUPercent > 50 {
+		t.Errorf("busiest thread = %+v, want worker-1 at 50%% CPU", threads[0])
+	}
+	for _, thread := range threads {
+		if !thread.CPUMeasured {
+			t.Errorf("thread %d is not measured on the second call", thread.TID)
+		}
+		if thread.TID != 1042 && thread.CPUPercent != 0 {
+			t.Errorf("idle thread %d at %.2f%% CPU, want 0", thread.TID, thread.CPUPercent)
+		}
+	}
+}
+
+func TestProcfsProcessManagerCached(t *testing.T) {
+	root := t.TempDir()
+	writeSyntheticProcfs(t, root, 3)
+	manager := NewProcfsProcessManager(root, t.TempDir())
+
+	opts := ProcessOptions{SortBy: SortByPID, Limit: 10}
+	if _, err := manager.GetProcesses(opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "3")); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.Ascending, opts.Cached = true, true
+	processes, err := manager.GetProcesses(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processes) != 3 {
+		t.Fatalf("cached GetProcesses = %d processes, want the 3 of the previous scan", len(processes))
+	}
+	if processes[0].PID != 1 {
+		t.Errorf("cached GetProcesses starts at PID %d, want 1", processes[0].PID)
+	}
+
+	opts.Cached = false
+	if processes, err = manager.GetProcesses(opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(processes) != 2 {
+		t.Fatalf("GetProcesses = %d processes after a rescan, want 2", len(processes))
+	}
+}
+
+// benchmarkPIDs is the number of processes in the synthetic procfs tree.
+const benchmarkPIDs = 5000
+
+// writeSyntheticProcfs creates a procfs tree with n processes below root.
+// The system wide files are copied from the testdata tree.
+func writeSyntheticProcfs(tb testing.TB, root string, n int) {
+	tb.Helper()
+
+	for _, name := range []string{"stat", "meminfo", "uptime", "loadavg", "vmstat"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "procfs", name))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), data, 0o644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	for pid := 1; pid <= n; pid++ {
+		dir := filepath.Join(root, fmt.Sprint(pid))
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			tb.Fatal(err)
+		}
+
+		files := map[string]string{
+			"stat": fmt.Sprintf("%d (worker %d) S 1 %d %d 0 -1 4194560 1000 0 10 0 %d %d 0 0 20 0 1 0 %d 122880000 3000 "+
+				"18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 %d 0 0 0 0 0 0 0 0 0 0 0 0 0\n",
+				pid, pid, pid, pid, pid*3, pid, 1000+pid, pid%4),
+			"statm":  "30000 3000 1000 100 0 2000 0\n",
+			"status": fmt.Sprintf("Name:\tworker %d\nState:\tS (sleeping)\nPid:\t%d\nPPid:\t1\nUid:\t1000\t1000\t1000\t1000\nGid:\t1000\t1000\t1000\t1000\nThreads:\t1\n", pid, pid),
+			"io":     fmt.Sprintf("rchar: 0\nwchar: 0\nsyscr: 0\nsyscw: 0\nread_bytes: %d\nwrite_bytes: %d\ncancelled_write_bytes: 0\n", pid*4096, pid*512),
+			"cgroup": fmt.Sprintf("0::/system.slice/worker-%d.service\n", pid%50),
+			"comm":   fmt.Sprintf("worker %d\n", pid),
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkProcfsCollector(b *testing.B) {
+	root := b.TempDir()
+	writeSyntheticProcfs(b, root, benchmarkPIDs)
+	collector := newProcfsCollector(root)
+
+	benchmarkCollector(b, collector.collect)
+}
+
+// BenchmarkDefaultProcessManager measures the gopsutil based reading the
+// procfs collector replaced, on the same synthetic tree. The processes are
+// built from the PIDs of the tree, since gopsutil skips PIDs that do not
+// exist in the current PID namespace unless the tree is a mount point.
+func BenchmarkDefaultProcessManager(b *testing.B) {
+	root := b.TempDir()
+	writeSyntheticProcfs(b, root, benchmarkPIDs)
+	b.Setenv("HOST_PROC", root)
+
+	benchmarkCollector(b, func() ([]ProcessInfo, error) {
+		pids, err := process.Pids()
+		if err != nil {
+			return nil, err
+		}
+		procs := make([]*process.Process, len(pids))
+		for i, pid := range pids {
+			procs[i] = &process.Process{Pid: pid}
+		}
+		return readProcesses(procs), nil
+	})
+}
+
+// benchmarkCollector reads all processes of the synthetic tree per iteration
+// and reports the time per process.
+func benchmarkCollector(b *testing.B, collect func() ([]ProcessInfo, error)) {
+	var processes []ProcessInfo
+	for b.Loop() {
+		var err error
+		if processes, err = collect(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	if len(processes) != benchmarkPIDs {
+		b.Fatalf("read %d processes, want %d", len(processes), benchmarkPIDs)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*benchmarkPIDs), "ns/process")
+}
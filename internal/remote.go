@@ -0,0 +1,260 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// remoteTimeout bounds every request to an agent so a stalled connection
+// cannot freeze the UI.
+const remoteTimeout = 5 * time.Second
+
+// RemoteClient talks to a mintop agent started with "mintop agent".
+type RemoteClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewRemoteClient creates a client for the agent at addr, given as host:port
+// or as an http(s) URL, authenticating with token.
+func NewRemoteClient(addr, token string) *RemoteClient {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	return &RemoteClient{
+		baseURL: strings.TrimSuffix(addr, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: remoteTimeout},
+	}
+}
+
+// get fetches path and decodes the JSON response into out.
+func (c *RemoteClient) get(path string, out any) error {
+	return c.do(http.MethodGet, path, nil, out)
+}
+
+// post sends in as JSON to path and decodes the JSON response into out.
+func (c *RemoteClient) post(path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPost, path, bytes.NewReader(body), out)
+}
+
+func (c *RemoteClient) do(method, path string, body io.Reader, out any) error {
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var agentErr agentError
+		if err := json.NewDecoder(resp.Body).Decode(&agentErr); err != nil || agentErr.Error == "" {
+			return fmt.Errorf("agent: %s", resp.Status)
+		}
+		return remoteError(agentErr)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// remoteError restores the sentinel error behind an agent error code.
+func remoteError(e agentError) error {
+	switch e.Code {
+	case agentErrNotSupported:
+		return fmt.Errorf("agent: %w", ErrNotSupported)
+	case agentErrNotExist:
+		return fmt.Errorf("agent: %s: %w", e.Error, fs.ErrNotExist)
+	case agentErrPermission:
+		return fmt.Errorf("agent: %s: %w", e.Error, fs.ErrPermission)
+	}
+	return fmt.Errorf("agent: %s", e.Error)
+}
+
+// RemoteStatsFetcher is a StatsFetcher reading the stats of the host an agent runs on.
+type RemoteStatsFetcher struct {
+	client *RemoteClient
+}
+
+// NewRemoteStatsFetcher creates a StatsFetcher backed by the agent behind client.
+func NewRemoteStatsFetcher(client *RemoteClient) RemoteStatsFetcher {
+	return RemoteStatsFetcher{client: client}
+}
+
+// fetch requests path from the agent. Like LiveStatsFetcher it returns an
+// empty value rather than nil on errors, so the header can still render.
+func fetch[T any](client *RemoteClient, path string) (*T, error) {
+	v := new(T)
+	if err := client.get(path, v); err != nil {
+		return new(T), err
+	}
+	return v, nil
+}
+
+func (r RemoteStatsFetcher) HostInfo() (*host.InfoStat, error) {
+	return fetch[host.InfoStat](r.client, "/v1/host")
+}
+
+func (r RemoteStatsFetcher) CpuUsage() (*cpu.TimesStat, error) {
+	return fetch[cpu.TimesStat](r.client, "/v1/cpu")
+}
+
+func (r RemoteStatsFetcher) MemUsage() (*mem.VirtualMemoryStat, error) {
+	return fetch[mem.VirtualMemoryStat](r.client, "/v1/mem")
+}
+
+func (r RemoteStatsFetcher) SwapUsage() (*mem.SwapMemoryStat, error) {
+	return fetch[mem.SwapMemoryStat](r.client, "/v1/swap")
+}
+
+func (r RemoteStatsFetcher) LoadAvg() (*load.AvgStat, error) {
+	return fetch[load.AvgStat](r.client, "/v1/load")
+}
+
+func (r RemoteStatsFetcher) Pressure() (*PressureStat, error) {
+	stat, err := fetch[PressureStat](r.client, "/v1/pressure")
+	if err != nil {
+		return nil, err
+	}
+	return stat, nil
+}
+
+func (r RemoteStatsFetcher) Sensors() (*SensorStat, error) {
+	return fetch[SensorStat](r.client, "/v1/sensors")
+}
+
+func (r RemoteStatsFetcher) Power() (*PowerStat, error) {
+	return fetch[PowerStat](r.client, "/v1/power")
+}
+
+// snapshot fetches the stats together with the process data selected by req
+// in a single request.
+func (r RemoteStatsFetcher) snapshot(req snapshotRequest) (*snapshotResponse, error) {
+	resp := new(snapshotResponse)
+	if err := r.client.post("/v1/snapshot", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// err returns the error the agent reported for part, or nil.
+func (s *snapshotResponse) err(part string) error {
+	if e, ok := s.Errors[part]; ok {
+		return remoteError(e)
+	}
+	return nil
+}
+
+// frame fills f with the data of the snapshot and logs the errors of failed
+// parts. Missing stats are replaced by empty values, like fetch does, so the
+// header can still render.
+func (s *snapshotResponse) frame(f frame) frame {
+	f.HostInfo = orNew(s.HostInfo)
+	f.CpuUsage = orNew(s.CpuUsage)
+	f.MemUsage = orNew(s.MemUsage)
+	f.SwapUsage = orNew(s.SwapUsage)
+	f.LoadAvg = orNew(s.LoadAvg)
+	f.Pressure = s.Pressure
+	f.Sensors = orNew(s.Sensors)
+	f.Power = orNew(s.Power)
+	f.TaskSummary = s.Summary
+
+	f.processes = s.Processes
+	f.groups = s.Groups
+	f.ports = s.Ports
+	f.threads = s.Threads
+	f.openFiles = s.OpenFiles
+	f.detailErr = s.err("details")
+
+	for part := range s.Errors {
+		err := s.err(part)
+		if part == "pressure" && errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		slog.Error("Failed to get snapshot part", "part", part, "error", err)
+	}
+	return f
+}
+
+// orNew returns v, or a new zero value when v is nil.
+func orNew[T any](v *T) *T {
+	if v == nil {
+		return new(T)
+	}
+	return v
+}
+
+// RemoteProcessManager is a ProcessManager listing the processes of the host an agent runs on.
+type RemoteProcessManager struct {
+	client  *RemoteClient
+	summary TaskSummary
+}
+
+// NewRemoteProcessManager creates a ProcessManager backed by the agent behind client.
+func NewRemoteProcessManager(client *RemoteClient) *RemoteProcessManager {
+	return &RemoteProcessManager{client: client}
+}
+
+func (m *RemoteProcessManager) GetProcesses(opts ProcessOptions) ([]ProcessInfo, error) {
+	var resp processesResponse
+	if err := m.client.post("/v1/processes", opts, &resp); err != nil {
+		return nil, err
+	}
+	m.summary = resp.Summary
+	return resp.Processes, nil
+}
+
+func (m *RemoteProcessManager) GetGroups(opts ProcessOptions) ([]ProcessGroup, error) {
+	var resp groupsResponse
+	if err := m.client.post("/v1/groups", opts, &resp); err != nil {
+		return nil, err
+	}
+	m.summary = resp.Summary
+	return resp.Groups, nil
+}
+
+func (m *RemoteProcessManager) TaskSummary() TaskSummary {
+	return m.summary
+}
+
+func (m *RemoteProcessManager) GetThreads(pid int32) ([]ThreadInfo, error) {
+	var threads []ThreadInfo
+	err := m.client.get(fmt.Sprintf("/v1/processes/%d/threads", pid), &threads)
+	return threads, err
+}
+
+func (m *RemoteProcessManager) GetOpenFiles(pid int32) ([]OpenFile, error) {
+	var files []OpenFile
+	err := m.client.get(fmt.Sprintf("/v1/processes/%d/files", pid), &files)
+	return files, err
+}
+
+func (m *RemoteProcessManager) GetListeningPorts() ([]ListeningPort, error) {
+	var ports []ListeningPort
+	err := m.client.get("/v1/ports", &ports)
+	return ports, err
+}
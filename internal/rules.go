@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProcessMatcher selects processes by PID, name pattern or user. Set fields
+// must all match, and * in Name stands for any characters, as in "our-service*".
+type ProcessMatcher struct {
+	PID  int32  `json:"pid,omitempty"`
+	Name string `json:"name,omitempty"`
+	User string `json:"user,omitempty"`
+}
+
+// Matches reports whether the process matches every set field.
+func (m ProcessMatcher) Matches(p ProcessInfo) bool {
+	if m.PID != 0 && m.PID != p.PID {
+		return false
+	}
+	if m.User != "" && m.User != p.Username {
+		return false
+	}
+	if m.Name != "" && !matchName(m.Name, p.Name) {
+		return false
+	}
+	return true
+}
+
+// validate rejects matchers that would match every process.
+func (m ProcessMatcher) validate() error {
+	if m.PID == 0 && m.Name == "" && m.User == "" {
+		return errors.New("matcher needs a pid, name or user")
+	}
+	return nil
+}
+
+// matchName reports whether name matches pattern, in which * stands for
+// any run of characters. Unlike path.Match, * also matches slashes, which
+// are common in kernel thread names such as "kworker/0:1".
+func matchName(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+// pinned reports whether any of the pins matches the process.
+func pinned(pins []ProcessMatcher, p ProcessInfo) bool {
+	for _, pin := range pins {
+		if pin.Matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// HighlightRule styles the table rows of the processes it matches.
+type HighlightRule struct {
+	ProcessMatcher
+	Color      lipgloss.Color `json:"color,omitempty"`
+	Background lipgloss.Color `json:"background,omitempty"`
+	Bold       bool           `json:"bold,omitempty"`
+}
+
+// style returns the row style of the rule.
+func (r HighlightRule) style() lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if r.Color != "" {
+		style = style.Foreground(r.Color)
+	}
+	if r.Background != "" {
+		style = style.Background(r.Background)
+	}
+	if r.Bold {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+// highlightStyle combines the styles of the rules matching the process.
+// When rules set the same property, the first matching rule wins.
+func highlightStyle(rules []HighlightRule, p ProcessInfo) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	for _, rule := range rules {
+		if rule.Matches(p) {
+			style = style.Inherit(rule.style())
+		}
+	}
+	return style
+}
@@ -84,3 +84,33 @@ func safeMemoryInfo(p *process.Process) *process.MemoryInfoStat {
 	}
 	return memInfo
 }
+
+// safeProcessState returns the single letter state of the process, as found in /proc/PID/stat.
+func safeProcessState(p *process.Process) string {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error(fmt.Sprintf("Recovered from panic: %v", r))
+		}
+	}()
+	status, err := p.Status()
+	if err != nil || len(status) == 0 {
+		return "?"
+	}
+
+	switch status[0] {
+	case process.Running:
+		return StateRunning
+	case process.Sleep:
+		return StateSleeping
+	case process.Blocked:
+		return StateUninterruptible
+	case process.Zombie:
+		return StateZombie
+	case process.Stop:
+		return StateStopped
+	case process.Idle:
+		return StateIdle
+	default:
+		return "?"
+	}
+}
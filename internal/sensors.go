@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Temperature is a single temperature sensor reading in degrees Celsius.
+// High and Critical are 0 when the sensor reports no threshold.
+type Temperature struct {
+	Name     string
+	Current  float64
+	High     float64
+	Critical float64
+}
+
+// SensorStat holds the current CPU frequencies and temperatures.
+type SensorStat struct {
+	// CPUFreqs holds the current frequency of each CPU in MHz, indexed by CPU number.
+	// CPUs without cpufreq support are reported as 0.
+	CPUFreqs     []float64
+	Temperatures []Temperature
+}
+
+// readSensors reads CPU frequencies and temperatures below the sysfs root.
+// Temperatures come from hwmon, falling back to thermal zones on systems
+// without hwmon drivers. Missing files are skipped rather than treated as errors.
+func readSensors(sysfsRoot string) *SensorStat {
+	temps := readHwmonTemperatures(sysfsRoot)
+	if len(temps) == 0 {
+		temps = readThermalZones(sysfsRoot)
+	}
+	return &SensorStat{
+		CPUFreqs:     readCPUFreqs(sysfsRoot),
+		Temperatures: temps,
+	}
+}
+
+// readCPUFreqs reads scaling_cur_freq of every CPU.
+func readCPUFreqs(sysfsRoot string) []float64 {
+	paths, _ := filepath.Glob(filepath.Join(sysfsRoot, "devices", "system", "cpu", "cpu[0-9]*", "cpufreq", "scaling_cur_freq"))
+
+	var freqs []float64
+	for _, path := range paths {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(filepath.Dir(path))), "cpu"))
+		if err != nil {
+			continue
+		}
+		khz, ok := readSysfsFloat(path)
+		if !ok {
+			continue
+		}
+		for len(freqs) <= cpu {
+			freqs = append(freqs, 0)
+		}
+		freqs[cpu] = khz / 1000
+	}
+	return freqs
+}
+
+// readHwmonTemperatures reads the temp*_input sensors of every hwmon device.
+// Sensors are named after their label, or the device name and sensor number.
+func readHwmonTemperatures(sysfsRoot string) []Temperature {
+	inputs, _ := filepath.Glob(filepath.Join(sysfsRoot, "class", "hwmon", "hwmon*", "temp*_input"))
+	sort.Strings(inputs)
+
+	var temps []Temperature
+	for _, input := range inputs {
+		dir := filepath.Dir(input)
+		prefix := strings.TrimSuffix(filepath.Base(input), "_input")
+
+		current, ok := readSysfsFloat(input)
+		if !ok {
+			continue
+		}
+
+		name := readSysfsString(filepath.Join(dir, prefix+"_label"))
+		if name == "" {
+			name = readSysfsString(filepath.Join(dir, "name")) + " " + strings.TrimPrefix(prefix, "temp")
+		}
+
+		high, _ := readSysfsFloat(filepath.Join(dir, prefix+"_max"))
+		critical, _ := readSysfsFloat(filepath.Join(dir, prefix+"_crit"))
+
+		temps = append(temps, Temperature{
+			Name:     name,
+			Current:  current / 1000,
+			High:     high / 1000,
+			Critical: critical / 1000,
+		})
+	}
+	return temps
+}
+
+// readThermalZones reads the thermal zones, taking the "hot" and "critical"
+// trip points as the high and critical thresholds.
+func readThermalZones(sysfsRoot string) []Temperature {
+	zones, _ := filepath.Glob(filepath.Join(sysfsRoot, "class", "thermal", "thermal_zone*"))
+	sort.Strings(zones)
+
+	var temps []Temperature
+	for _, zone := range zones {
+		current, ok := readSysfsFloat(filepath.Join(zone, "temp"))
+		if !ok {
+			continue
+		}
+
+		temp := Temperature{
+			Name:    readSysfsString(filepath.Join(zone, "type")),
+			Current: current / 1000,
+		}
+
+		trips, _ := filepath.Glob(filepath.Join(zone, "trip_point_*_type"))
+		for _, trip := range trips {
+			value, ok := readSysfsFloat(strings.TrimSuffix(trip, "_type") + "_temp")
+			if !ok {
+				continue
+			}
+			switch readSysfsString(trip) {
+			case "hot":
+				temp.High = value / 1000
+			case "critical":
+				temp.Critical = value / 1000
+			}
+		}
+
+		temps = append(temps, temp)
+	}
+	return temps
+}
+
+// readSysfsString reads a sysfs attribute, returning "" when it is missing.
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysfsFloat reads a numeric sysfs attribute.
+func readSysfsFloat(path string) (float64, bool) {
+	v, err := strconv.ParseFloat(readSysfsString(path), 64)
+	return v, err == nil
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadHwmonTemperatures(t *testing.T) {
+	temps := readHwmonTemperatures(filepath.Join("testdata", "sysfs"))
+
+	// Labelled inputs are named after their label, unlabelled ones after
+	// the device and sensor number, and millidegrees are converted.
+	want := []Temperature{
+		{Name: "Package id 0", Current: 88, High: 84, Critical: 100},
+		{Name: "Core 0", Current: 71, High: 84, Critical: 100},
+		{Name: "Core 1", Current: 101, High: 84, Critical: 100},
+		{Name: "nvme 1", Current: 42.85, High: 81.85, Critical: 84.85},
+	}
+	if !slices.Equal(temps, want) {
+		t.Errorf("readHwmonTemperatures = %+v, want %+v", temps, want)
+	}
+}
+
+func TestReadHwmonTemperaturesWithoutThresholds(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"class/hwmon/hwmon0/name":        "acpitz",
+		"class/hwmon/hwmon0/temp1_input": "27800",
+		"class/hwmon/hwmon0/temp2_input": "unavailable",
+	})
+
+	temps := readHwmonTemperatures(root)
+	if want := []Temperature{{Name: "acpitz 1", Current: 27.8}}; !slices.Equal(temps, want) {
+		t.Errorf("readHwmonTemperatures = %+v, want %+v", temps, want)
+	}
+}
+
+func TestReadThermalZones(t *testing.T) {
+	temps := readThermalZones(filepath.Join("testdata", "sysfs"))
+
+	want := []Temperature{{Name: "acpitz", Current: 52, High: 95, Critical: 105}}
+	if !slices.Equal(temps, want) {
+		t.Errorf("readThermalZones = %+v, want %+v", temps, want)
+	}
+}
+
+func TestReadCPUFreqs(t *testing.T) {
+	freqs := readCPUFreqs(filepath.Join("testdata", "sysfs"))
+	if want := []float64{3400, 1200, 2800, 800}; !slices.Equal(freqs, want) {
+		t.Errorf("readCPUFreqs = %v, want %v", freqs, want)
+	}
+
+	// CPUs without cpufreq are reported as 0.
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"devices/system/cpu/cpu0/cpufreq/scaling_cur_freq": "2000000",
+		"devices/system/cpu/cpu2/cpufreq/scaling_cur_freq": "1500000",
+	})
+	if freqs, want := readCPUFreqs(root), []float64{2000, 0, 1500}; !slices.Equal(freqs, want) {
+		t.Errorf("readCPUFreqs with a gap = %v, want %v", freqs, want)
+	}
+}
+
+func TestReadSensors(t *testing.T) {
+	sensors := readSensors(filepath.Join("testdata", "sysfs"))
+	if len(sensors.CPUFreqs) != 4 || len(sensors.Temperatures) != 4 || sensors.Temperatures[0].Name != "Package id 0" {
+		t.Errorf("readSensors = %+v, want 4 CPUs and the hwmon temperatures", sensors)
+	}
+
+	// Thermal zones are used on systems without hwmon sensors.
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"class/thermal/thermal_zone0/type": "x86_pkg_temp",
+		"class/thermal/thermal_zone0/temp": "61000",
+	})
+	sensors = readSensors(root)
+	if want := []Temperature{{Name: "x86_pkg_temp", Current: 61}}; !slices.Equal(sensors.Temperatures, want) {
+		t.Errorf("temperatures without hwmon = %+v, want %+v", sensors.Temperatures, want)
+	}
+
+	sensors = readSensors(filepath.Join(t.TempDir(), "missing"))
+	if sensors == nil || len(sensors.CPUFreqs) != 0 || len(sensors.Temperatures) != 0 {
+		t.Errorf("readSensors of a missing sysfs root = %+v, want no sensors", sensors)
+	}
+}
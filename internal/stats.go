@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"context"
 	"log/slog"
+	"sync"
 
+	"github.com/shirou/gopsutil/v4/common"
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/host"
 	"github.com/shirou/gopsutil/v4/load"
@@ -17,13 +20,53 @@ type StatsFetcher interface {
 	MemUsage() (*mem.VirtualMemoryStat, error)
 	SwapUsage() (*mem.SwapMemoryStat, error)
 	LoadAvg() (*load.AvgStat, error)
+	Pressure() (*PressureStat, error)
+	Sensors() (*SensorStat, error)
+	Power() (*PowerStat, error)
 }
 
 // LiveStatsFetcher is the production implementation of StatsFetcher that uses gopsutil.
-type LiveStatsFetcher struct{}
+// ProcfsRoot and SysfsRoot point gopsutil at an alternative /proc and /sys,
+// such as a fixture tree or the host's procfs mounted into a container.
+// Empty values keep gopsutil's defaults.
+type LiveStatsFetcher struct {
+	ProcfsRoot string
+	SysfsRoot  string
+
+	// prevCPU keeps the CPU times of the previous CpuUsage call. It is
+	// shared by copies of the fetcher, and nil in a zero value.
+	prevCPU *cpuSample
+}
+
+// cpuSample holds CPU times read by CpuUsage.
+type cpuSample struct {
+	mu    sync.Mutex
+	times *cpu.TimesStat
+}
+
+// NewLiveStatsFetcher creates a LiveStatsFetcher reading from the given procfs and sysfs roots.
+func NewLiveStatsFetcher(procfsRoot, sysfsRoot string) LiveStatsFetcher {
+	return LiveStatsFetcher{
+		ProcfsRoot: procfsRoot,
+		SysfsRoot:  sysfsRoot,
+		prevCPU:    &cpuSample{},
+	}
+}
+
+// context returns the context passed to gopsutil, carrying the configured roots.
+func (l LiveStatsFetcher) context() context.Context {
+	env := common.EnvMap{}
+	if l.ProcfsRoot != "" {
+		env[common.HostProcEnvKey] = l.ProcfsRoot
+	}
+	if l.SysfsRoot != "" {
+		env[common.HostSysEnvKey] = l.SysfsRoot
+	}
+	return context.WithValue(context.Background(), common.EnvKey, env)
+}
 
 func (l LiveStatsFetcher) HostInfo() (*host.InfoStat, error) {
-	info, err := host.Info()
+	info, err := host.InfoWithContext(l.context())
 	if err != nil {
 		return &host.InfoStat{}, err
 	}
@@ -31,40 +74,75 @@ func (l LiveStatsFetcher) HostInfo() (*host.InfoStat, error) {
 	return info, nil
 }
 
+// CpuUsage returns the share of each CPU time category in percent. The
+// shares cover the time since the previous call, however long ago that
+// was, so they follow the refresh interval. The first call covers the time
+// since boot.
 func (l LiveStatsFetcher) CpuUsage() (*cpu.TimesStat, error) {
-	cpuTimes, err := cpu.Times(false)
+	cpuTimes, err := cpu.TimesWithContext(l.context(), false)
 	if err != nil || len(cpuTimes) == 0 {
 		slog.Error("Failed to get CPU stats", "error", err)
 		return &cpu.TimesStat{}, err
 	}
 
 	currStats := cpuTimes[0]
+	delta := currStats
+	if l.prevCPU != nil {
+		l.prevCPU.mu.Lock()
+		if prev := l.prevCPU.times; prev != nil {
+			delta = cpuTimesDelta(currStats, *prev)
+		}
+		l.prevCPU.times = &currStats
+		l.prevCPU.mu.Unlock()
+	}
 
-	// Calculate total time
-	total := currStats.User + currStats.System + currStats.Idle + currStats.Nice +
-		currStats.Iowait + currStats.Irq + currStats.Softirq + currStats.Steal +
-		currStats.Guest
+	// Calculate total time. Guest time is already accounted in User
+	// and guest nice time in Nice, so they are not added again.
+	total := delta.User + delta.System + delta.Idle + delta.Nice +
+		delta.Iowait + delta.Irq + delta.Softirq + delta.Steal
 
-	if total == 0 {
-		return &cpu.TimesStat{}, nil
+	if total <= 0 {
+		return &cpu.TimesStat{CPU: currStats.CPU, Idle: 100}, nil
 	}
 
 	// Overwrite TimesStat fields with percentage values
-	currStats.User = (currStats.User / total) * 100
-	currStats.System = (currStats.System / total) * 100
-	currStats.Idle = (currStats.Idle / total) * 100
-	currStats.Nice = (currStats.Nice / total) * 100
-	currStats.Iowait = (currStats.Iowait / total) * 100
-	currStats.Irq = (currStats.Irq / total) * 100
-	currStats.Softirq = (currStats.Softirq / total) * 100
-	currStats.Steal = (currStats.Steal / total) * 100
-	currStats.Guest = (currStats.Guest / total) * 100
-
-	return &currStats, nil
+	delta.User = (delta.User / total) * 100
+	delta.System = (delta.System / total) * 100
+	delta.Idle = (delta.Idle / total) * 100
+	delta.Nice = (delta.Nice / total) * 100
+	delta.Iowait = (delta.Iowait / total) * 100
+	delta.Irq = (delta.Irq / total) * 100
+	delta.Softirq = (delta.Softirq / total) * 100
+	delta.Steal = (delta.Steal / total) * 100
+	delta.Guest = (delta.Guest / total) * 100
+	delta.GuestNice = (delta.GuestNice / total) * 100
+
+	return &delta, nil
+}
+
+// cpuTimesDelta returns the CPU times spent between prev and curr.
+// Counters that went backwards, such as after a CPU went offline, count as zero.
+func cpuTimesDelta(curr, prev cpu.TimesStat) cpu.TimesStat {
+	diff := func(c, p float64) float64 {
+		return max(c-p, 0)
+	}
+	return cpu.TimesStat{
+		CPU:       curr.CPU,
+		User:      diff(curr.User, prev.User),
+		System:    diff(curr.System, prev.System),
+		Idle:      diff(curr.Idle, prev.Idle),
+		Nice:      diff(curr.Nice, prev.Nice),
+		Iowait:    diff(curr.Iowait, prev.Iowait),
+		Irq:       diff(curr.Irq, prev.Irq),
+		Softirq:   diff(curr.Softirq, prev.Softirq),
+		Steal:     diff(curr.Steal, prev.Steal),
+		Guest:     diff(curr.Guest, prev.Guest),
+		GuestNice: diff(curr.GuestNice, prev.GuestNice),
+	}
 }
 
 func (l LiveStatsFetcher) MemUsage() (*mem.VirtualMemoryStat, error) {
-	v, err := mem.VirtualMemory()
+	v, err := mem.VirtualMemoryWithContext(l.context())
 	if err != nil {
 		return &mem.VirtualMemoryStat{}, err
 	}
@@ -79,9 +157,38 @@ func (l LiveStatsFetcher) MemUsage() (*mem.VirtualMemoryStat, error) {
 }
 
 func (l LiveStatsFetcher) SwapUsage() (*mem.SwapMemoryStat, error) {
-	return mem.SwapMemory()
+	return mem.SwapMemoryWithContext(l.context())
 }
 
 func (l LiveStatsFetcher) LoadAvg() (*load.AvgStat, error) {
-	return load.Avg()
+	return load.AvgWithContext(l.context())
+}
+
+// Pressure reads Pressure Stall Information. Kernels built without PSI
+// return an error wrapping os.ErrNotExist.
+func (l LiveStatsFetcher) Pressure() (*PressureStat, error) {
+	root := l.ProcfsRoot
+	if root == "" {
+		root = "/proc"
+	}
+	return readPressure(root)
+}
+
+// Sensors reads CPU frequencies and temperatures from sysfs.
+// Sensors that are not exposed, e.g. inside VMs, are left out.
+func (l LiveStatsFetcher) Sensors() (*SensorStat, error) {
+	root := l.SysfsRoot
+	if root == "" {
+		root = "/sys"
+	}
+	return readSensors(root), nil
+}
+
+// Power reads the batteries and AC adapter from sysfs.
+func (l LiveStatsFetcher) Power() (*PowerStat, error) {
+	root := l.SysfsRoot
+	if root == "" {
+		root = "/sys"
+	}
+	return readPower(root), nil
 }
@@ -0,0 +1,310 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Column defines a table column.
+// Style, when set, returns the style of a cell in this column based on its value.
+// Sort is what a click on the column header sorts by, empty when the column is not sortable.
+type Column struct {
+	Title string
+	Width int
+	Style func(value string) lipgloss.Style
+	Sort  SortCriteria
+}
+
+// Row is a single table row with one value per column.
+type Row []string
+
+// TableStyles contains the styles used to render a Table.
+type TableStyles struct {
+	Header   lipgloss.Style
+	Cell     lipgloss.Style
+	Selected lipgloss.Style
+}
+
+// DefaultTableStyles returns the default table styles.
+func DefaultTableStyles() TableStyles {
+	return TableStyles{
+		Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
+		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
+		Cell:     lipgloss.NewStyle().Padding(0, 1),
+	}
+}
+
+// Table is a scrollable table with a cursor. It follows the API of the bubbles
+// table but renders each cell itself so that columns can style their values.
+type Table struct {
+	cols    []Column
+	rows    []Row
+	styles  TableStyles
+	height  int
+	width   int
+	cursor  int
+	offset  int
+	focused bool
+	// rowStyles, when set, holds a style per row that its cells inherit.
+	rowStyles []lipgloss.Style
+}
+
+// NewTable creates a new table. The height includes the header line.
+func NewTable(cols []Column, height int, styles TableStyles) Table {
+	return Table{
+		cols:    cols,
+		styles:  styles,
+		height:  height,
+		focused: true,
+	}
+}
+
+// Focused returns whether the table accepts navigation.
+func (t Table) Focused() bool {
+	return t.focused
+}
+
+// Focus focuses the table.
+func (t *Table) Focus() {
+	t.focused = true
+}
+
+// Blur removes the focus from the table.
+func (t *Table) Blur() {
+	t.focused = false
+}
+
+// SetStyles sets the table styles.
+func (t *Table) SetStyles(s TableStyles) {
+	t.styles = s
+}
+
+// Columns returns the current columns.
+func (t Table) Columns() []Column {
+	return t.cols
+}
+
+// SetColumns sets the columns. Rows that do not match the new columns are dropped.
+func (t *Table) SetColumns(cols []Column) {
+	t.cols = cols
+	t.rows = nil
+	t.rowStyles = nil
+	t.clampCursor()
+}
+
+// Rows returns the current rows.
+func (t Table) Rows() []Row {
+	return t.rows
+}
+
+// SetRows replaces the rows, keeping the cursor on the same index where possible.
+// It clears the row styles.
+func (t *Table) SetRows(rows []Row) {
+	t.rows = rows
+	t.rowStyles = nil
+	t.clampCursor()
+}
+
+// SetRowStyles sets a style per row, such as a highlight color. Column
+// styles and the selection take precedence over it.
+func (t *Table) SetRowStyles(styles []lipgloss.Style) {
+	t.rowStyles = styles
+}
+
+// SelectedRow returns the row under the cursor, or nil if the table is empty.
+func (t Table) SelectedRow() Row {
+	if t.cursor < 0 || t.cursor >= len(t.rows) {
+		return nil
+	}
+	return t.rows[t.cursor]
+}
+
+// Cursor returns the index of the selected row.
+func (t Table) Cursor() int {
+	return t.cursor
+}
+
+// SetCursor moves the cursor to row n.
+func (t *Table) SetCursor(n int) {
+	t.cursor = n
+	t.clampCursor()
+}
+
+// MoveUp moves the cursor up by n rows.
+func (t *Table) MoveUp(n int) {
+	t.SetCursor(t.cursor - n)
+}
+
+// MoveDown moves the cursor down by n rows.
+func (t *Table) MoveDown(n int) {
+	t.SetCursor(t.cursor + n)
+}
+
+// GotoTop moves the cursor to the first row.
+func (t *Table) GotoTop() {
+	t.SetCursor(0)
+}
+
+// GotoBottom moves the cursor to the last row.
+func (t *Table) GotoBottom() {
+	t.SetCursor(len(t.rows) - 1)
+}
+
+// PageUp moves the cursor up by one page of visible rows.
+func (t *Table) PageUp() {
+	t.MoveUp(t.bodyHeight())
+}
+
+// PageDown moves the cursor down by one page of visible rows.
+func (t *Table) PageDown() {
+	t.MoveDown(t.bodyHeight())
+}
+
+// HalfPageUp moves the cursor up by half a page of visible rows.
+func (t *Table) HalfPageUp() {
+	t.MoveUp(max(t.bodyHeight()/2, 1))
+}
+
+// HalfPageDown moves the cursor down by half a page of visible rows.
+func (t *Table) HalfPageDown() {
+	t.MoveDown(max(t.bodyHeight()/2, 1))
+}
+
+// Height returns the height of the table including the header.
+func (t Table) Height() int {
+	return t.height
+}
+
+// SetHeight sets the height of the table including the header.
+func (t *Table) SetHeight(h int) {
+	t.height = h
+	t.clampCursor()
+}
+
+// Width returns the width the rows are clipped to, 0 when they are not clipped.
+func (t Table) Width() int {
+	return t.width
+}
+
+// SetWidth sets the width the header and rows are clipped to, so that they
+// do not wrap on narrow terminals. A width of 0 disables clipping.
+func (t *Table) SetWidth(w int) {
+	t.width = w
+}
+
+// View renders the table.
+func (t Table) View() string {
+	lines := make([]string, 0, t.height)
+	lines = append(lines, t.headersView())
+
+	end := min(t.offset+t.bodyHeight(), len(t.rows))
+	for i := t.offset; i < end; i++ {
+		lines = append(lines, t.renderRow(i))
+	}
+	for len(lines) < t.height {
+		lines = append(lines, "")
+	}
+
+	view := strings.Join(lines, "\n")
+	if t.width > 0 {
+		view = lipgloss.NewStyle().MaxWidth(t.width).Render(view)
+	}
+	return view
+}
+
+// RowAt returns the index of the row rendered at line y of the table view.
+// It returns false for the header and the empty lines below the last row.
+func (t Table) RowAt(y int) (int, bool) {
+	if y < 1 || y > t.bodyHeight() {
+		return 0, false
+	}
+	row := t.offset + y - 1
+	return row, row < len(t.rows)
+}
+
+// ColumnAt returns the index of the column rendered at cell x of the table view, or -1.
+func (t Table) ColumnAt(x int) int {
+	left := 0
+	for i, col := range t.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		right := left + col.Width + t.styles.Header.GetHorizontalFrameSize()
+		if x >= left && x < right {
+			return i
+		}
+		left = right
+	}
+	return -1
+}
+
+// bodyHeight returns the number of rows visible below the header.
+func (t Table) bodyHeight() int {
+	return max(t.height-1, 1)
+}
+
+// clampCursor keeps the cursor within the rows and scrolls it into view.
+func (t *Table) clampCursor() {
+	t.cursor = clamp(t.cursor, 0, max(len(t.rows)-1, 0))
+
+	body := t.bodyHeight()
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+body {
+		t.offset = t.cursor - body + 1
+	}
+	t.offset = clamp(t.offset, 0, max(len(t.rows)-body, 0))
+}
+
+func (t Table) headersView() string {
+	cells := make([]string, 0, len(t.cols))
+	for _, col := range t.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
+		cells = append(cells, t.styles.Header.Render(style.Render(runewidth.Truncate(col.Title, col.Width, "…"))))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
+
+func (t Table) renderRow(r int) string {
+	selected := r == t.cursor
+
+	cells := make([]string, 0, len(t.cols))
+	for i, col := range t.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		value := ""
+		if i < len(t.rows[r]) {
+			value = t.rows[r][i]
+		}
+
+		style := lipgloss.NewStyle()
+		if col.Style != nil {
+			style = col.Style(value)
+		}
+		cellStyle := t.styles.Cell
+		if r < len(t.rowStyles) {
+			style = style.Inherit(t.rowStyles[r])
+			cellStyle = cellStyle.Inherit(t.rowStyles[r])
+		}
+		if selected {
+			style = t.styles.Selected.Inherit(style)
+			cellStyle = cellStyle.Inherit(t.styles.Selected)
+		}
+
+		content := style.Width(col.Width).MaxWidth(col.Width).Inline(true).
+			Render(runewidth.Truncate(value, col.Width, "…"))
+		cells = append(cells, cellStyle.Render(content))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
+
+func clamp(v, low, high int) int {
+	return min(max(v, low), high)
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTableSetWidthClipsLines(t *testing.T) {
+	table := NewTable([]Column{
+		{Title: "PID", Width: 8},
+		{Title: "Command", Width: 60},
+		{Title: "Cgroup", Width: 60},
+	}, 3, DefaultTableStyles())
+	table.SetRows([]Row{
+		{"1", "systemd", "/init.scope"},
+		{"42", strings.Repeat("x", 80), "/system.slice/worker.service"},
+	})
+
+	if width := lipgloss.Width(table.View()); width <= 40 {
+		t.Fatalf("unclipped view is %d cells wide, want more than 40", width)
+	}
+
+	table.SetWidth(40)
+	view := table.View()
+	if lines := strings.Count(view, "\n") + 1; lines != 3 {
+		t.Errorf("clipped view has %d lines, want 3", lines)
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if width := lipgloss.Width(line); width > 40 {
+			t.Errorf("line %d is %d cells wide, want at most 40", i, width)
+		}
+	}
+	if !strings.HasPrefix(strings.TrimSpace(view), "PID") {
+		t.Errorf("clipped view does not start with the header:\n%s", view)
+	}
+}
+
+// newTestTable returns a table showing 3 of 10 rows below its header.
+func newTestTable() Table {
+	table := NewTable([]Column{{Title: "PID", Width: 6}, {Title: "Name", Width: 10}}, 4, DefaultTableStyles())
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i] = Row{fmt.Sprint(i), fmt.Sprintf("proc-%d", i)}
+	}
+	table.SetRows(rows)
+	return table
+}
+
+// visibleRows returns the first cell of the rows rendered below the header.
+func visibleRows(table Table) []string {
+	var cells []string
+	for _, line := range strings.Split(table.View(), "\n")[1:] {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			cells = append(cells, fields[0])
+		}
+	}
+	return cells
+}
+
+func TestTableCursor(t *testing.T) {
+	table := newTestTable()
+
+	table.MoveDown(2)
+	if got := table.Cursor(); got != 2 {
+		t.Errorf("cursor = %d after moving down 2, want 2", got)
+	}
+	if row := table.SelectedRow(); row[0] != "2" {
+		t.Errorf("SelectedRow = %v, want row 2", row)
+	}
+
+	table.MoveUp(5)
+	if got := table.Cursor(); got != 0 {
+		t.Errorf("cursor = %d after moving up past the top, want 0", got)
+	}
+
+	table.GotoBottom()
+	if got := table.Cursor(); got != 9 {
+		t.Errorf("cursor = %d at the bottom, want 9", got)
+	}
+	table.MoveDown(1)
+	if got := table.Cursor(); got != 9 {
+		t.Errorf("cursor = %d after moving down past the bottom, want 9", got)
+	}
+
+	table.PageUp()
+	if got := table.Cursor(); got != 6 {
+		t.Errorf("cursor = %d after a page up from 9, want 6", got)
+	}
+	table.GotoTop()
+	if got := table.Cursor(); got != 0 {
+		t.Errorf("cursor = %d at the top, want 0", got)
+	}
+}
+
+func TestTableScrolling(t *testing.T) {
+	table := newTestTable()
+
+	if got := strings.Join(visibleRows(table), " "); got != "0 1 2" {
+		t.Errorf("visible rows = %s, want 0 1 2", got)
+	}
+
+	// The view follows the cursor once it moves past the last visible row.
+	table.SetCursor(4)
+	if got := strings.Join(visibleRows(table), " "); got != "2 3 4" {
+		t.Errorf("visible rows with the cursor on 4 = %s, want 2 3 4", got)
+	}
+	if row, ok := table.RowAt(3); !ok || row != 4 {
+		t.Errorf("RowAt(3) = %d, %t, want 4, true", row, ok)
+	}
+
+	// Moving up within the visible rows does not scroll.
+	table.MoveUp(1)
+	if got := strings.Join(visibleRows(table), " "); got != "2 3 4" {
+		t.Errorf("visible rows with the cursor on 3 = %s, want 2 3 4", got)
+	}
+
+	table.GotoBottom()
+	if got := strings.Join(visibleRows(table), " "); got != "7 8 9" {
+		t.Errorf("visible rows at the bottom = %s, want 7 8 9", got)
+	}
+	if _, ok := table.RowAt(0); ok {
+		t.Error("RowAt(0) returned a row for the header line")
+	}
+}
+
+func TestTableSetRowsAndColumns(t *testing.T) {
+	table := newTestTable()
+	table.SetCursor(8)
+
+	// Fewer rows clamp the cursor and the scroll offset.
+	table.SetRows([]Row{{"0", "a"}, {"1", "b"}})
+	if got := table.Cursor(); got != 1 {
+		t.Errorf("cursor = %d after shrinking to 2 rows, want 1", got)
+	}
+	if got := strings.Join(visibleRows(table), " "); got != "0 1" {
+		t.Errorf("visible rows = %s, want 0 1", got)
+	}
+
+	// New columns drop the rows, which no longer match them.
+	table.SetColumns([]Column{{Title: "TID", Width: 6}})
+	if len(table.Rows()) != 0 || table.SelectedRow() != nil {
+		t.Errorf("rows = %v after SetColumns, want none", table.Rows())
+	}
+	if header := strings.Fields(strings.Split(table.View(), "\n")[0]); len(header) != 1 || header[0] != "TID" {
+		t.Errorf("header = %v, want TID", header)
+	}
+
+	// Zero width columns are hidden.
+	table.SetColumns([]Column{{Title: "PID", Width: 6}, {Title: "Hidden", Width: 0}, {Title: "Name", Width: 10}})
+	table.SetRows([]Row{{"1", "x", "init"}})
+	if got := strings.Fields(strings.Split(table.View(), "\n")[1]); len(got) != 2 || got[1] != "init" {
+		t.Errorf("row = %v, want the hidden column skipped", got)
+	}
+	if got := table.ColumnAt(8); got != 2 {
+		t.Errorf("ColumnAt(8) = %d, want 2 past the hidden column", got)
+	}
+}
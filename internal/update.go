@@ -1,11 +1,15 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strconv"
 	"time"
 
-	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -15,44 +19,198 @@ func (m Model) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.processTable.SetWidth(msg.Width)
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		if m.prompting {
+			return m.updatePrompt(msg)
+		}
+		m.promptErr = ""
+
+		keys := m.keyMap()
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
-		case "up", "k":
-			if m.processTable.Focused() {
-				m.processTable.MoveUp(1)
+		case key.Matches(msg, keys.Help):
+			m.showHelp = !m.showHelp
+		case key.Matches(msg, keys.Up):
+			return m.moveCursor(func(t *Table) { t.MoveUp(1) }), nil
+		case key.Matches(msg, keys.Down):
+			return m.moveCursor(func(t *Table) { t.MoveDown(1) }), nil
+		case key.Matches(msg, keys.PageUp):
+			return m.moveCursor((*Table).PageUp), nil
+		case key.Matches(msg, keys.PageDown):
+			return m.moveCursor((*Table).PageDown), nil
+		case key.Matches(msg, keys.HalfPageUp):
+			return m.moveCursor((*Table).HalfPageUp), nil
+		case key.Matches(msg, keys.HalfPageDown):
+			return m.moveCursor((*Table).HalfPageDown), nil
+		case key.Matches(msg, keys.Top):
+			return m.moveCursor((*Table).GotoTop), nil
+		case key.Matches(msg, keys.Bottom):
+			return m.moveCursor((*Table).GotoBottom), nil
+		case key.Matches(msg, keys.JumpToPID):
+			m.prompting = true
+			m.pidInput.Reset()
+			return m, m.pidInput.Focus()
+		case key.Matches(msg, keys.Pin):
+			return m.togglePin()
+		case key.Matches(msg, keys.Threads):
+			return m.enterDetailView(modeThreads)
+		case key.Matches(msg, keys.OpenFiles):
+			return m.enterDetailView(modeOpenFiles)
+		case key.Matches(msg, keys.Ports):
+			return m.enterPortsView()
+		case key.Matches(msg, keys.Group):
+			return m.cycleGrouping()
+		case key.Matches(msg, keys.ExpandCPU):
+			m.cpuExpanded = !m.cpuExpanded
+		case key.Matches(msg, keys.Pause):
+			return m.togglePause(), nil
+		case key.Matches(msg, keys.Step):
+			return m.stepFrame(), nil
+		case key.Matches(msg, keys.Slower):
+			m.config.RefreshInterval = nextRefreshInterval(m.config.RefreshInterval)
+			return m.restartTicks()
+		case key.Matches(msg, keys.Faster):
+			m.config.RefreshInterval = previousRefreshInterval(m.config.RefreshInterval)
+			return m.restartTicks()
+		case key.Matches(msg, keys.Select):
+			if m.mode == modePorts {
+				return m.jumpToPort()
 			}
-		case "down", "j":
-			if m.processTable.Focused() {
-				m.processTable.MoveDown(1)
+			return m.expandGroup()
+		case key.Matches(msg, keys.Sort):
+			return m.cycleSort()
+		case key.Matches(msg, keys.Reverse):
+			m.processOptions.Ascending = !m.processOptions.Ascending
+			return m.applySort()
+		case key.Matches(msg, keys.Back):
+			if m.showHelp {
+				m.showHelp = false
+				return m, nil
+			}
+			if m.mode == modeGroups {
+				return m.exitGrouping()
+			}
+			if m.mode == modeProcesses && m.processOptions.Group != "" {
+				return m.collapseGroup()
+			}
+			if m.mode != modeProcesses {
+				return m.enterProcessView(), nil
 			}
-		case "esc":
 			if m.processTable.Focused() {
 				m.tableStyle.Selected = m.baseStyle
 				m.processTable.SetStyles(m.tableStyle)
 				m.processTable.Blur()
 			} else {
-				m.tableStyle.Selected = lipgloss.NewStyle().Background(lipgloss.Color("62"))
+				m.tableStyle.Selected = lipgloss.NewStyle().Background(m.config.Colors.TableSelectionBackground)
 				m.processTable.SetStyles(m.tableStyle)
 				m.processTable.Focus()
 			}
 		}
 
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
 	// Handle the TickMsg to update system stats
 	case TickMsg:
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		if fetcher, ok := m.statsFetcher.(snapshotFetcher); ok {
+			return m, m.fetchSnapshot(fetcher, msg.Time, false)
+		}
+		if m.paused {
+			return m.bufferFrame(msg.Time), m.tickEvery()
+		}
 		m = m.updateStats()
-		m.lastUpdate = time.Time(msg)
+		m.lastUpdate = msg.Time
 		m.hasLoaded = true
 
 		return m, m.tickEvery()
+
+	case snapshotMsg:
+		if msg.initial {
+			return m.showLoadedFrame(msg.frame), nil
+		}
+		m = m.receiveFrame(msg.frame)
+		// The tick loop was restarted while the snapshot was in flight.
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		return m, m.tickEvery()
+
+	case jumpMsg:
+		if !m.showsView(msg.frame) {
+			return m, nil
+		}
+		m.followPID = msg.pid
+		return m.showLoadedFrame(msg.frame).checkJump(msg.pid, msg.previous), nil
 	}
 
 	return m, nil
 }
 
+// snapshotFetcher is implemented by stats fetchers that fetch all data of a
+// refresh in a single request, such as RemoteStatsFetcher.
+type snapshotFetcher interface {
+	snapshot(req snapshotRequest) (*snapshotResponse, error)
+}
+
+// snapshotMsg carries a frame fetched in the background.
+type snapshotMsg struct {
+	frame frame
+	// initial is set for the fetch loading a view outside of the tick loop,
+	// which must not schedule another tick.
+	initial bool
+	// gen is the generation of the tick loop the fetch was made for.
+	gen int
+}
+
+// jumpMsg carries the frame loaded to select pid from the jump to PID prompt.
+type jumpMsg struct {
+	snapshotMsg
+	pid      int32
+	previous int32
+}
+
+// fetchSnapshot fetches the data of the tick at t in the background, so that
+// a slow agent does not block the UI. The next tick is scheduled when the
+// result arrives, so only one fetch is in flight at a time.
+func (m Model) fetchSnapshot(fetcher snapshotFetcher, t time.Time, initial bool) tea.Cmd {
+	req := snapshotRequest{Options: m.processOptions, PID: m.detailPID}
+	if m.followPID != 0 {
+		req.Options.Include = append(slices.Clone(req.Options.Include), m.followPID)
+	}
+	switch m.mode {
+	case modeGroups:
+		req.Groups = true
+	case modePorts:
+		req.Ports = true
+	case modeThreads:
+		req.Threads = true
+	case modeOpenFiles:
+		req.OpenFiles = true
+	}
+	f := frame{time: t, mode: m.mode, detailPID: m.detailPID, options: m.processOptions}
+	gen := m.tickGen
+
+	return func() tea.Msg {
+		resp, err := fetcher.snapshot(req)
+		if err != nil {
+			slog.Error("Failed to get snapshot", "error", err)
+			resp = &snapshotResponse{}
+		}
+		f = resp.frame(f)
+		if err != nil && (f.mode == modeThreads || f.mode == modeOpenFiles) {
+			f.detailErr = err
+		}
+		return snapshotMsg{frame: f, initial: initial, gen: gen}
+	}
+}
+
 func (m Model) updateStats() Model {
 	var err error
 	// Update Host info every minute
@@ -84,26 +242,548 @@ func (m Model) updateStats() Model {
 		slog.Error("Failed to get Load Average", "error", err)
 	}
 
-	processes, err := m.processManager.GetProcesses(m.processOptions)
+	m.Pressure, err = m.statsFetcher.Pressure()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error("Failed to get Pressure Stall Information", "error", err)
+	}
+
+	m.Sensors, err = m.statsFetcher.Sensors()
+	if err != nil {
+		slog.Error("Failed to get sensors", "error", err)
+	}
+
+	m.Power, err = m.statsFetcher.Power()
+	if err != nil {
+		slog.Error("Failed to get power supplies", "error", err)
+	}
+
+	m = m.updateProcesses()
+	switch m.mode {
+	case modeProcesses, modeGroups:
+	case modePorts:
+		m = m.updatePorts()
+	default:
+		m = m.updateDetails()
+	}
+
+	return m.refreshTable()
+}
+
+// reload fetches the data of the current view after an action changed it.
+// Remote hosts are fetched in the background like on a tick, so a slow agent
+// does not hold up the keys, while local data is read right away.
+func (m Model) reload() (Model, tea.Cmd) {
+	if fetcher, ok := m.statsFetcher.(snapshotFetcher); ok {
+		cmd := m.fetchSnapshot(fetcher, time.Now(), true)
+		// The followed process may only be in the fetched list, so it is
+		// kept even when the rows shown until then do not include it.
+		follow := m.followPID
+		m = m.refreshTable()
+		m.followPID = follow
+		return m, cmd
+	}
+
+	switch m.mode {
+	case modeProcesses, modeGroups:
+		m = m.reloadProcesses()
+	case modePorts:
+		m = m.updatePorts()
+	default:
+		m = m.updateDetails()
+	}
+	return m.refreshTable(), nil
+}
+
+// showLoadedFrame shows a frame loaded by an action. Unlike a tick, it is
+// shown even while paused, as the local data read by the action is.
+func (m Model) showLoadedFrame(f frame) Model {
+	if !m.showsView(f) {
+		return m
+	}
+	m = m.showFrame(f)
+	m.hasLoaded = true
+	return m
+}
+
+// updateProcesses scans the process list, or the process groups in modeGroups.
+func (m Model) updateProcesses() Model {
+	return m.fetchProcesses(false)
+}
+
+// reloadProcesses re-sorts and filters the processes of the last scan after
+// the options changed, keeping the CPU and I/O rates of the last refresh.
+func (m Model) reloadProcesses() Model {
+	return m.fetchProcesses(true)
+}
+
+// fetchProcesses fetches the process list, or the process groups in modeGroups.
+func (m Model) fetchProcesses(cached bool) Model {
+	opts := m.processOptions
+	opts.Cached = cached
+	if m.mode == modeGroups {
+		return m.updateGroups(opts)
+	}
+
+	if m.followPID != 0 {
+		opts.Include = append(slices.Clone(opts.Include), m.followPID)
+	}
+
+	var err error
+	m.processes, err = m.processManager.GetProcesses(opts)
 	if err != nil {
 		slog.Error("Failed to get process info", "error", err)
-	} else {
-		var rows []table.Row
-		for _, p := range processes {
-			rows = append(rows, table.Row{
+	}
+	m.TaskSummary = m.processManager.TaskSummary()
+	return m
+}
+
+func (m Model) updateGroups(opts ProcessOptions) Model {
+	var err error
+	m.groups, err = m.processManager.GetGroups(opts)
+	if err != nil {
+		slog.Error("Failed to get process groups", "error", err)
+	}
+	m.TaskSummary = m.processManager.TaskSummary()
+	return m
+}
+
+func (m Model) updatePorts() Model {
+	var err error
+	m.ports, err = m.processManager.GetListeningPorts()
+	if err != nil {
+		slog.Error("Failed to get listening ports", "error", err)
+	}
+	return m
+}
+
+// updateDetails fetches the data of the sub-view showing details of detailPID.
+func (m Model) updateDetails() Model {
+	switch m.mode {
+	case modeThreads:
+		m.threads, m.detailErr = m.processManager.GetThreads(m.detailPID)
+	case modeOpenFiles:
+		m.openFiles, m.detailErr = m.processManager.GetOpenFiles(m.detailPID)
+	}
+	if m.detailErr != nil {
+		slog.Error("Failed to get process details", "pid", m.detailPID, "error", m.detailErr)
+	}
+	return m
+}
+
+// refreshTable fills the table with the rows of the current view mode.
+func (m Model) refreshTable() Model {
+	var rows []Row
+	var styles []lipgloss.Style
+	switch m.mode {
+	case modeThreads:
+		for _, t := range m.threads {
+			cpuPercent := "-"
+			if t.CPUMeasured {
+				cpuPercent = fmt.Sprintf("%.2f%%", t.CPUPercent)
+			}
+			rows = append(rows, Row{
+				fmt.Sprintf("%d", t.TID),
+				t.Name,
+				t.State,
+				cpuPercent,
+				fmt.Sprintf("%d", t.LastCPU),
+			})
+		}
+	case modeOpenFiles:
+		for _, f := range m.openFiles {
+			protocol, remote, state := "", "", ""
+			if f.Socket != nil {
+				protocol, remote, state = f.Socket.Protocol, f.Socket.RemoteAddr(), f.Socket.State
+			}
+			rows = append(rows, Row{
+				fmt.Sprintf("%d", f.FD),
+				f.Type,
+				protocol,
+				f.Name(),
+				remote,
+				state,
+			})
+		}
+	case modeGroups:
+		for _, g := range m.groups {
+			row := Row{
+				g.Name,
+				fmt.Sprintf("%d", g.Count),
+				fmt.Sprintf("%d", g.Threads),
+				fmt.Sprintf("%.2f%%", g.CPUPercent),
+				fmt.Sprintf("%.2fMB", g.MemoryUsage),
+			}
+			if m.processOptions.GroupBy == GroupByCgroup {
+				row = append(row, cgroupLimitCells(g.Limits)...)
+			}
+			if m.processOptions.GroupBy == GroupByName {
+				row = append(row, formatStartTime(g.FirstStart), formatStartTime(g.LastStart))
+			}
+			rows = append(rows, row)
+		}
+	case modePorts:
+		for _, p := range m.ports {
+			pid := "-"
+			if p.PID != 0 {
+				pid = fmt.Sprintf("%d", p.PID)
+			}
+			rows = append(rows, Row{
+				p.Protocol,
+				p.LocalIP,
+				fmt.Sprintf("%d", p.LocalPort),
+				pid,
+				p.Name,
+				p.State,
+			})
+		}
+	default:
+		for _, p := range m.processes {
+			name := p.Name
+			if pinned(m.processOptions.Pins, p) {
+				name = "• " + name
+			}
+			if len(m.config.Highlights) > 0 {
+				styles = append(styles, highlightStyle(m.config.Highlights, p))
+			}
+			rows = append(rows, Row{
 				fmt.Sprintf("%d", p.PID),
 				fmt.Sprintf("%d", p.ParentPID),
-				p.Name,
+				name,
+				p.State,
+				fmt.Sprintf("%d", p.NumThreads),
 				fmt.Sprintf("%.2f%%", p.CPUPercent),
 				fmt.Sprintf("%.2f%%", p.MemoryPercent),
 				fmt.Sprintf("%.2fMB", p.MemoryUsage),
+				formatIORate(p.ReadPerSec, p.IOAvailable),
+				formatIORate(p.WritePerSec, p.IOAvailable),
 				p.Username,
+				p.Container,
 				p.RunningTime,
 			})
 		}
+	}
+
+	m.processTable.SetRows(rows)
+	m.processTable.SetRowStyles(styles)
+
+	// Keep the followed process selected while the list re-sorts. When it
+	// is gone, follow whichever process took its place under the cursor.
+	if m.mode == modeProcesses && m.followPID != 0 {
+		found := false
+		for i, p := range m.processes {
+			if p.PID == m.followPID {
+				m.processTable.SetCursor(i)
+				found = true
+			}
+		}
+		if !found {
+			m.followPID = 0
+			if p, ok := m.selectedProcess(); ok {
+				m.followPID = p.PID
+			}
+		}
+	}
+	return m
+}
+
+// moveCursor moves the table cursor with move and makes the newly
+// selected process the one followed across refreshes.
+func (m Model) moveCursor(move func(*Table)) Model {
+	move(&m.processTable)
+	m.followPID = 0
+	if p, ok := m.selectedProcess(); ok {
+		m.followPID = p.PID
+	}
+	return m
+}
+
+// togglePin pins the selected process by its PID, or unpins it when it
+// is pinned by PID. Processes pinned by name or user stay pinned.
+func (m Model) togglePin() (Model, tea.Cmd) {
+	p, ok := m.selectedProcess()
+	if !ok {
+		return m, nil
+	}
+
+	pin := ProcessMatcher{PID: p.PID}
+	if i := slices.Index(m.processOptions.Pins, pin); i >= 0 {
+		m.processOptions.Pins = slices.Delete(slices.Clone(m.processOptions.Pins), i, i+1)
+	} else {
+		m.processOptions.Pins = append(slices.Clone(m.processOptions.Pins), pin)
+	}
+
+	m.followPID = p.PID
+	return m.reload()
+}
+
+// updatePrompt handles keys while the jump to PID prompt is open.
+func (m Model) updatePrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.prompting = false
+		m.pidInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.prompting = false
+		m.pidInput.Blur()
+		pid, err := strconv.ParseInt(m.pidInput.Value(), 10, 32)
+		if err != nil || pid <= 0 {
+			m.promptErr = fmt.Sprintf("invalid PID %q", m.pidInput.Value())
+			return m, nil
+		}
+		return m.jumpToPID(int32(pid))
+	}
+
+	var cmd tea.Cmd
+	m.pidInput, cmd = m.pidInput.Update(msg)
+	return m, cmd
+}
+
+// jumpToPID selects the process with the given PID, fetching it when it
+// falls beyond the process limit. Remote processes are selected once the
+// process list including pid arrives.
+func (m Model) jumpToPID(pid int32) (Model, tea.Cmd) {
+	previous := m.followPID
+	m.followPID = pid
 
-		m.processTable.SetRows(rows)
+	if fetcher, ok := m.statsFetcher.(snapshotFetcher); ok {
+		fetch := m.fetchSnapshot(fetcher, time.Now(), true)
+		m.followPID = previous
+		return m, func() tea.Msg {
+			return jumpMsg{snapshotMsg: fetch().(snapshotMsg), pid: pid, previous: previous}
+		}
 	}
 
+	m = m.reloadProcesses()
+	m = m.refreshTable()
+	return m.checkJump(pid, previous), nil
+}
+
+// checkJump reports a jump to a PID that is not in the process list and
+// goes back to following the previous process.
+func (m Model) checkJump(pid, previous int32) Model {
+	if p, ok := m.selectedProcess(); !ok || p.PID != pid {
+		m.promptErr = fmt.Sprintf("no process with PID %d", pid)
+		m.followPID = previous
+	}
 	return m
 }
+
+// keyMap returns the configured key bindings with only those enabled that
+// apply to the current view, and descriptions matching what they do there.
+func (m Model) keyMap() KeyMap {
+	keys := m.config.Keys
+	focused := m.processTable.Focused()
+	processes := m.mode == modeProcesses
+	listing := processes || m.mode == modeGroups
+
+	for _, binding := range []*key.Binding{
+		&keys.Up, &keys.Down, &keys.PageUp, &keys.PageDown,
+		&keys.HalfPageUp, &keys.HalfPageDown, &keys.Top, &keys.Bottom,
+	} {
+		binding.SetEnabled(focused)
+	}
+	keys.JumpToPID.SetEnabled(processes && focused)
+	keys.Pin.SetEnabled(processes && focused)
+	if p, ok := m.selectedProcess(); ok && slices.Contains(m.processOptions.Pins, ProcessMatcher{PID: p.PID}) {
+		keys.Pin.SetHelp(keys.Pin.Help().Key, "unpin process")
+	}
+	keys.Threads.SetEnabled(processes && focused)
+	keys.OpenFiles.SetEnabled(processes && focused)
+	keys.Ports.SetEnabled(processes)
+	keys.Group.SetEnabled(listing)
+	keys.Sort.SetEnabled(listing)
+	keys.Reverse.SetEnabled(listing)
+	keys.Step.SetEnabled(m.paused && len(m.pending) > 0)
+	if m.paused {
+		keys.Pause.SetHelp(keys.Pause.Help().Key, "resume")
+	}
+
+	switch {
+	case m.mode == modePorts && focused:
+		keys.Select.SetHelp(keys.Select.Help().Key, "go to process")
+	case m.mode == modeGroups && focused:
+		keys.Select.SetHelp(keys.Select.Help().Key, "expand group")
+	default:
+		keys.Select.SetEnabled(false)
+	}
+
+	switch {
+	case m.showHelp:
+		keys.Back.SetHelp(keys.Back.Help().Key, "close help")
+	case m.atTopLevel() && focused:
+		keys.Back.SetHelp(keys.Back.Help().Key, "unfocus table")
+	case m.atTopLevel():
+		keys.Back.SetHelp(keys.Back.Help().Key, "focus table")
+	}
+	return keys
+}
+
+// atTopLevel reports whether the model shows the plain process list,
+// where esc has no view to go back to.
+func (m Model) atTopLevel() bool {
+	return m.mode == modeProcesses && m.processOptions.Group == ""
+}
+
+// selectedProcess returns the process under the table cursor.
+func (m Model) selectedProcess() (ProcessInfo, bool) {
+	cursor := m.processTable.Cursor()
+	if m.mode != modeProcesses || cursor >= len(m.processes) {
+		return ProcessInfo{}, false
+	}
+	return m.processes[cursor], true
+}
+
+// enterDetailView switches the table to a sub-view with details of the selected process.
+func (m Model) enterDetailView(mode viewMode) (Model, tea.Cmd) {
+	p, ok := m.selectedProcess()
+	if !ok {
+		return m, nil
+	}
+
+	m.mode = mode
+	m.detailPID = p.PID
+	m.detailName = p.Name
+	m.processTable.SetColumns(m.columns())
+	m.processTable.GotoTop()
+
+	return m.reload()
+}
+
+// enterProcessView switches the table back to the process list.
+func (m Model) enterProcessView() Model {
+	m.mode = modeProcesses
+	m.threads = nil
+	m.openFiles = nil
+	m.ports = nil
+	m.detailErr = nil
+	m.processTable.SetColumns(m.columns())
+	m.processTable.GotoTop()
+
+	return m.refreshTable()
+}
+
+// enterPortsView switches the table to the listening ports.
+func (m Model) enterPortsView() (Model, tea.Cmd) {
+	m.mode = modePorts
+	m.processTable.SetColumns(m.columns())
+	m.processTable.GotoTop()
+
+	return m.reload()
+}
+
+// jumpToPort selects the process owning the selected port in the process list.
+func (m Model) jumpToPort() (Model, tea.Cmd) {
+	cursor := m.processTable.Cursor()
+	if cursor >= len(m.ports) || m.ports[cursor].PID == 0 {
+		return m, nil
+	}
+
+	pid := m.ports[cursor].PID
+	m = m.enterProcessView()
+	m.followPID = pid
+	return m.reload()
+}
+
+// cycleGrouping moves the process list to the next grouping criteria.
+func (m Model) cycleGrouping() (Model, tea.Cmd) {
+	next := groupCriteriaOrder[0]
+	for i, criteria := range groupCriteriaOrder {
+		if criteria == m.processOptions.GroupBy {
+			next = groupCriteriaOrder[(i+1)%len(groupCriteriaOrder)]
+		}
+	}
+
+	if next == GroupByNone {
+		return m.exitGrouping()
+	}
+	m.processOptions.GroupBy = next
+	return m.enterGroupView()
+}
+
+// exitGrouping switches the table back to the ungrouped process list.
+func (m Model) exitGrouping() (Model, tea.Cmd) {
+	m.processOptions.GroupBy = GroupByNone
+	m.processOptions.Group = ""
+	m = m.enterProcessView()
+	return m.reload()
+}
+
+// enterGroupView switches the table to the process groups.
+func (m Model) enterGroupView() (Model, tea.Cmd) {
+	m.mode = modeGroups
+	m.processOptions.Group = ""
+	m.groupName = ""
+	m.processTable.SetColumns(m.columns())
+	m.processTable.GotoTop()
+
+	return m.reload()
+}
+
+// expandGroup switches the table to the processes of the selected group.
+func (m Model) expandGroup() (Model, tea.Cmd) {
+	cursor := m.processTable.Cursor()
+	if cursor >= len(m.groups) {
+		return m, nil
+	}
+
+	m.processOptions.Group = m.groups[cursor].Key
+	m.groupName = m.groups[cursor].Name
+	m = m.enterProcessView()
+	return m.reload()
+}
+
+// collapseGroup switches the table from the processes of a group back to the groups.
+func (m Model) collapseGroup() (Model, tea.Cmd) {
+	return m.enterGroupView()
+}
+
+// cycleSort moves the process list to the next sort criteria.
+func (m Model) cycleSort() (Model, tea.Cmd) {
+	next := sortCriteriaOrder[0]
+	for i, criteria := range sortCriteriaOrder {
+		if criteria == m.processOptions.SortBy {
+			next = sortCriteriaOrder[(i+1)%len(sortCriteriaOrder)]
+		}
+	}
+	m.processOptions.SortBy = next
+	return m.applySort()
+}
+
+// applySort re-sorts the processes with the current sort options and updates the header.
+func (m Model) applySort() (Model, tea.Cmd) {
+	m.processTable.SetColumns(m.columns())
+	return m.reload()
+}
+
+// cgroupLimitCells formats the limit columns of a cgroup group.
+// Missing controllers are shown as "-" and unlimited values as "max".
+func cgroupLimitCells(limits *CgroupLimits) []string {
+	cells := []string{"-", "-", "-", "-", "-", "-"}
+	if limits == nil {
+		return cells
+	}
+
+	if limits.HasMemory {
+		cells[0] = formatBytes(limits.MemoryCurrent) + "/max"
+		if limits.MemoryMax > 0 {
+			cells[0] = formatBytes(limits.MemoryCurrent) + "/" + formatBytes(limits.MemoryMax)
+			cells[1] = fmt.Sprintf("%.0f%%", float64(limits.MemoryCurrent)/float64(limits.MemoryMax)*100)
+		}
+		cells[5] = fmt.Sprintf("%d", limits.OOMKills)
+	}
+	if limits.HasCPU {
+		cells[2] = "max"
+		if limits.CPUQuota > 0 {
+			cells[2] = fmt.Sprintf("%.2f", limits.CPUQuota)
+		}
+		cells[3] = limits.ThrottledTime.Round(100 * time.Millisecond).String()
+	}
+	if limits.HasPids {
+		cells[4] = fmt.Sprintf("%d/max", limits.PidsCurrent)
+		if limits.PidsMax > 0 {
+			cells[4] = fmt.Sprintf("%d/%d", limits.PidsCurrent, limits.PidsMax)
+		}
+	}
+	return cells
+}
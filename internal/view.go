@@ -1,24 +1,69 @@
 package internal
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 func (m Model) View() string {
-	headerView := NewHeaderView(m.config, m.baseStyle, m.viewStyle)
-	processView := NewProcessView(m.viewStyle)
-
-	column := m.baseStyle.Width(m.width).Padding(1, 0, 0, 0).Render
+	header, body, footer := m.sections()
 
 	content := m.baseStyle.
 		Width(m.width).
 		Height(m.height).
-		Render(
-			lipgloss.JoinVertical(
-				lipgloss.Left,
-				column(headerView.Render(m)),
-				column(processView.Render(m.processTable)),
-			),
-		)
+		Render(lipgloss.JoinVertical(lipgloss.Left, header, body, footer))
 	return content
 }
+
+// sections renders the header, the body with the table or the help overlay,
+// and the footer. Mouse hit-testing measures the same renders as the view.
+func (m Model) sections() (header, body, footer string) {
+	headerView := NewHeaderView(m.config, m.baseStyle, m.viewStyle)
+	processView := NewProcessView(m.viewStyle)
+
+	column := m.baseStyle.Width(m.width).Padding(1, 0, 0, 0).Render
+
+	keys := m.keyMap()
+	m.help.Width = m.width
+
+	body = processView.Render(m)
+	if m.showHelp {
+		body = m.renderHelpOverlay(keys)
+	}
+
+	return column(headerView.Render(m)),
+		column(body),
+		column(m.baseStyle.Padding(0, 1).Render(m.footer(keys)))
+}
+
+// footer renders the jump to PID prompt while it is open, the error of a
+// failed jump, or the help bar, preceded by the pause indicator while paused.
+func (m Model) footer(keys KeyMap) string {
+	switch {
+	case m.prompting:
+		return m.pidInput.View()
+	case m.promptErr != "":
+		return m.baseStyle.Foreground(m.config.Colors.Warning).Render(m.promptErr)
+	}
+	if m.paused {
+		paused := m.baseStyle.Foreground(m.config.Colors.Warning).Bold(true).
+			Render(fmt.Sprintf("paused, %d ticks behind", len(m.pending)))
+		m.help.Width -= lipgloss.Width(paused) + 2
+		return paused + "  " + m.help.ShortHelpView(keys.ShortHelp())
+	}
+	return m.help.ShortHelpView(keys.ShortHelp())
+}
+
+// renderHelpOverlay renders every key binding of the current view in place of the table.
+func (m Model) renderHelpOverlay(keys KeyMap) string {
+	box := m.baseStyle.
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 2)
+
+	return box.Render(lipgloss.JoinVertical(lipgloss.Left,
+		m.baseStyle.Bold(true).Render("Key bindings"),
+		"",
+		m.help.FullHelpView(keys.FullHelp()),
+	))
+}
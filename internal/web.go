@@ -0,0 +1,241 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// Snapshot is the state published to web clients on every tick.
+type Snapshot struct {
+	Time      time.Time
+	Host      *host.InfoStat
+	CPU       *cpu.TimesStat
+	Memory    *mem.VirtualMemoryStat
+	Swap      *mem.SwapMemoryStat
+	Load      *load.AvgStat
+	Pressure  *PressureStat
+	Tasks     TaskSummary
+	Processes []ProcessInfo
+	// Signals is true when clients may send signals to processes.
+	Signals bool
+}
+
+// webSignals are the signals the web UI may send, by name.
+var webSignals = map[string]os.Signal{
+	"TERM": syscall.SIGTERM,
+	"KILL": os.Kill,
+}
+
+// WebServer serves a read-only web dashboard. A single collector loop scans
+// the processes every interval and pushes the snapshot to all connected
+// browsers over Server-Sent Events. Signals can only be sent when enabled
+// with a token, which requests must carry as a bearer token.
+type WebServer struct {
+	stats     StatsFetcher
+	processes ProcessManager
+	config    Config
+	// signalToken enables the signal endpoint when non-empty.
+	signalToken string
+
+	mu      sync.Mutex
+	latest  []byte
+	clients map[chan []byte]struct{}
+	mux     *http.ServeMux
+}
+
+// NewWebServer creates a WebServer. Pass an empty signalToken to keep it read-only.
+func NewWebServer(config Config, stats StatsFetcher, processes ProcessManager, signalToken string) *WebServer {
+	s := &WebServer{
+		stats:       stats,
+		processes:   processes,
+		config:      config,
+		signalToken: signalToken,
+		clients:     make(map[chan []byte]struct{}),
+		mux:         http.NewServeMux(),
+	}
+
+	static, _ := fs.Sub(webFiles, "web")
+	s.mux.Handle("GET /", http.FileServerFS(static))
+	s.mux.HandleFunc("GET /api/snapshot", s.handleSnapshot)
+	s.mux.HandleFunc("GET /events", s.handleEvents)
+	s.mux.HandleFunc("POST /api/processes/{pid}/signal", s.handleSignal)
+	return s
+}
+
+func (s *WebServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Run collects a snapshot every refresh interval until ctx is done.
+func (s *WebServer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		s.publish(s.collect())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect takes a snapshot of the stats and the top processes.
+func (s *WebServer) collect() Snapshot {
+	snapshot := Snapshot{Time: time.Now(), Signals: s.signalToken != ""}
+
+	var err error
+	if snapshot.Host, err = s.stats.HostInfo(); err != nil {
+		slog.Error("Failed to get Host info", "error", err)
+	}
+	if snapshot.CPU, err = s.stats.CpuUsage(); err != nil {
+		slog.Error("Failed to get CPU stats", "error", err)
+	}
+	if snapshot.Memory, err = s.stats.MemUsage(); err != nil {
+		slog.Error("Failed to get Memory stats", "error", err)
+	}
+	if snapshot.Swap, err = s.stats.SwapUsage(); err != nil {
+		slog.Error("Failed to get Swap Memory stats", "error", err)
+	}
+	if snapshot.Load, err = s.stats.LoadAvg(); err != nil {
+		slog.Error("Failed to get Load Average", "error", err)
+	}
+	// Pressure stays nil on kernels without PSI.
+	snapshot.Pressure, _ = s.stats.Pressure()
+
+	processes, err := s.processes.GetProcesses(ProcessOptions{SortBy: SortByCPU, Limit: s.config.ProcessLimit, Pins: s.config.Pins})
+	if err != nil {
+		slog.Error("Failed to get processes", "error", err)
+	}
+	snapshot.Processes = processes
+	snapshot.Tasks = s.processes.TaskSummary()
+
+	return snapshot
+}
+
+// publish stores the snapshot and pushes it to every connected client.
+// Slow clients skip snapshots rather than holding up the collector.
+func (s *WebServer) publish(snapshot Snapshot) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		slog.Error("Failed to encode snapshot", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = data
+	for client := range s.clients {
+		select {
+		case <-client:
+		default:
+		}
+		client <- data
+	}
+}
+
+func (s *WebServer) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data := s.latest
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// handleEvents streams snapshots as Server-Sent Events, starting with the latest one.
+func (s *WebServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan []byte, 1)
+	s.mu.Lock()
+	if s.latest != nil {
+		client <- s.latest
+	}
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-client:
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleSignal sends a signal to a process. It only exists when the server
+// was started with a signal token, and requires that token.
+func (s *WebServer) handleSignal(w http.ResponseWriter, r *http.Request) {
+	if s.signalToken == "" {
+		http.Error(w, "signals are disabled", http.StatusNotFound)
+		return
+	}
+	token, ok := bearerToken(r)
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.signalToken)) != 1 {
+		http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+		return
+	}
+
+	pid, err := pathPID(r)
+	if err != nil || pid <= 0 {
+		http.Error(w, "invalid pid", http.StatusBadRequest)
+		return
+	}
+
+	var req struct{ Signal string }
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	sig, ok := webSignals[req.Signal]
+	if !ok {
+		http.Error(w, "unsupported signal", http.StatusBadRequest)
+		return
+	}
+
+	process, err := os.FindProcess(int(pid))
+	if err == nil {
+		err = process.Signal(sig)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	slog.Info("Sent signal from web UI", "pid", pid, "signal", req.Signal, "remote", r.RemoteAddr)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// newTestWebServer serves a web server over fakes that published one snapshot.
+func newTestWebServer(t *testing.T, signalToken string) *httptest.Server {
+	t.Helper()
+
+	web := NewWebServer(*DefaultConfig(), fakeStats{}, newFakeProcesses(10), signalToken)
+	web.publish(web.collect())
+	server := httptest.NewServer(web)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// postSignal sends signal to pid through the web server with the given Authorization header.
+func postSignal(t *testing.T, server *httptest.Server, pid, signal, auth string) int {
+	t.Helper()
+
+	body := strings.NewReader(fmt.Sprintf(`{"Signal": %q}`, signal))
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/processes/"+pid+"/signal", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestWebSignalsDisabled(t *testing.T) {
+	server := newTestWebServer(t, "")
+
+	if status := postSignal(t, server, "1", "TERM", "Bearer "); status != http.StatusNotFound {
+		t.Errorf("status %d with signals disabled, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestWebSignalRequests(t *testing.T) {
+	server := newTestWebServer(t, "secret")
+
+	tests := []struct {
+		name   string
+		pid    string
+		signal string
+		auth   string
+		want   int
+	}{
+		{"missing token", "1", "TERM", "", http.StatusUnauthorized},
+		{"wrong token", "1", "TERM", "Bearer wrong", http.StatusUnauthorized},
+		{"not a bearer token", "1", "TERM", "secret", http.StatusUnauthorized},
+		{"unknown signal", "1", "HUP", "Bearer secret", http.StatusBadRequest},
+		{"non-numeric pid", "init", "TERM", "Bearer secret", http.StatusBadRequest},
+		{"zero pid", "0", "TERM", "Bearer secret", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := postSignal(t, server, tt.pid, tt.signal, tt.auth); status != tt.want {
+				t.Errorf("status %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebSignalSent(t *testing.T) {
+	server := newTestWebServer(t, "secret")
+
+	cmd := exec.Command("sleep", "60")
+	if err := cmd.Start(); err != nil {
+		t.Skip("cannot start sleep:", err)
+	}
+	t.Cleanup(func() { cmd.Process.Kill() })
+
+	if status := postSignal(t, server, fmt.Sprint(cmd.Process.Pid), "TERM", "Bearer secret"); status != http.StatusNoContent {
+		t.Fatalf("status %d, want %d", status, http.StatusNoContent)
+	}
+	var exitErr *exec.ExitError
+	if err := cmd.Wait(); !errors.As(err, &exitErr) || exitErr.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+		t.Errorf("sleep exited with %v, want SIGTERM", err)
+	}
+}
+
+func TestWebSnapshot(t *testing.T) {
+	server := newTestWebServer(t, "")
+
+	resp, err := http.Get(server.URL + "/api/snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var snapshot Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Host.Hostname != "fake" || len(snapshot.Processes) != 10 || snapshot.Tasks.Total != 10 || snapshot.Signals {
+		t.Errorf("snapshot of host %q with %d processes and signals %t, want fake with 10 and no signals",
+			snapshot.Host.Hostname, len(snapshot.Processes), snapshot.Signals)
+	}
+}
+
+func TestWebEvents(t *testing.T) {
+	server := newTestWebServer(t, "secret")
+
+	resp, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	// The latest snapshot is sent as soon as the stream opens.
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+	if !ok {
+		t.Fatalf("first event line = %q, want a data line", line)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Host.Hostname != "fake" || !snapshot.Signals {
+		t.Errorf("event of host %q with signals %t, want fake with signals", snapshot.Host.Hostname, snapshot.Signals)
+	}
+}
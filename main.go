@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +17,11 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "agent" {
+		runAgent(os.Args[2:])
+		return
+	}
+
 	logFile, err := os.OpenFile("mintop.log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		fmt.Println("Error opening log file:", err)
@@ -26,16 +35,127 @@ func main() {
 
 	// Define and parse the refresh interval flag
 	refreshInterval := flag.Duration("refresh", time.Second, "Set the refresh interval for system stats")
+	procfsRoot := flag.String("procfs", "/proc", "Read process and system stats from this procfs root")
+	sysfsRoot := flag.String("sysfs", "/sys", "Read hardware stats and cgroup limits from this sysfs root")
+	connect := flag.String("connect", "", "Monitor the host of the mintop agent at this host:port instead of the local host, or several comma separated agents in an overview")
+	token := flag.String("token", os.Getenv("MINTOP_TOKEN"), "Token for the agent given with -connect, or for signals sent from the web UI (default $MINTOP_TOKEN)")
+	httpAddr := flag.String("http", "", "Serve a read-only web dashboard of the local host on this address instead of starting the terminal UI")
+	configPath := flag.String("config", "", "Read settings such as key bindings from this JSON file (default "+internal.DefaultConfigPath()+")")
+	mouse := flag.Bool("mouse", true, "Select rows, sort columns and scroll with the mouse; disable to select text with the mouse")
+	httpSignals := flag.Bool("http-signals", false, "Allow sending signals to processes from the web dashboard, authenticated with -token")
 	flag.Parse()
 
-	config := internal.DefaultConfig().WithRefreshInterval(*refreshInterval)
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Println("Error reading config:", err)
+		os.Exit(1)
+	}
+	config = config.WithRefreshInterval(*refreshInterval)
 
-	fetcher := internal.LiveStatsFetcher{}
-	processMananger := internal.NewProcessManager()
+	if *httpAddr != "" {
+		signalToken := ""
+		if *httpSignals {
+			if *token == "" {
+				fmt.Println("-http-signals requires a token, set -token or MINTOP_TOKEN")
+				os.Exit(1)
+			}
+			signalToken = *token
+		}
+		runWeb(config, *httpAddr, *procfsRoot, *sysfsRoot, signalToken)
+		return
+	}
+
+	var model tea.Model
+	switch addrs := strings.Split(*connect, ","); {
+	case len(addrs) > 1:
+		model = internal.NewDashboard(config, addrs, *token)
+	case *connect != "":
+		client := internal.NewRemoteClient(*connect, *token)
+		model = internal.NewModel(config, internal.NewRemoteStatsFetcher(client), internal.NewRemoteProcessManager(client))
+	default:
+		fetcher := internal.NewLiveStatsFetcher(*procfsRoot, *sysfsRoot)
+		model = internal.NewModel(config, fetcher, newProcessManager(*procfsRoot, *sysfsRoot))
+	}
 
-	p := tea.NewProgram(internal.NewModel(config, fetcher, processMananger), tea.WithAltScreen())
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if *mouse {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model, options...)
 	if _, err := p.Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
 }
+
+// loadConfig reads the configuration file at path, or the default
+// configuration file if it exists when path is empty.
+func loadConfig(path string) (internal.Config, error) {
+	if path == "" {
+		path = internal.DefaultConfigPath()
+		if _, err := os.Stat(path); path == "" || errors.Is(err, os.ErrNotExist) {
+			return *internal.DefaultConfig(), nil
+		}
+	}
+
+	config, err := internal.LoadConfig(path)
+	if err != nil {
+		return internal.Config{}, err
+	}
+	return *config, nil
+}
+
+// newProcessManager creates the process manager for the procfs root,
+// exiting when the root has no procfs.
+func newProcessManager(procfsRoot, sysfsRoot string) internal.ProcessManager {
+	processManager, err := internal.NewProcessManager(procfsRoot, sysfsRoot)
+	if err != nil {
+		fmt.Println("Error reading processes:", err)
+		os.Exit(1)
+	}
+	return processManager
+}
+
+// runAgent serves the local stats and processes to mintop clients started with -connect.
+func runAgent(args []string) {
+	flags := flag.NewFlagSet("agent", flag.ExitOnError)
+	listen := flags.String("listen", "localhost:7070", "Address to serve the agent on")
+	token := flags.String("token", os.Getenv("MINTOP_TOKEN"), "Token clients must present (default $MINTOP_TOKEN)")
+	procfsRoot := flags.String("procfs", "/proc", "Read process and system stats from this procfs root")
+	sysfsRoot := flags.String("sysfs", "/sys", "Read hardware stats and cgroup limits from this sysfs root")
+	flags.Parse(args)
+
+	if *token == "" {
+		fmt.Println("The agent requires a token, set -token or MINTOP_TOKEN")
+		os.Exit(1)
+	}
+
+	agent := internal.NewAgent(
+		internal.NewLiveStatsFetcher(*procfsRoot, *sysfsRoot),
+		newProcessManager(*procfsRoot, *sysfsRoot),
+		*token,
+	)
+
+	slog.Info("Serving mintop agent", "listen", *listen)
+	if err := http.ListenAndServe(*listen, agent); err != nil {
+		fmt.Println("Error running agent:", err)
+		os.Exit(1)
+	}
+}
+
+// runWeb serves the web dashboard of the local host.
+func runWeb(config internal.Config, addr, procfsRoot, sysfsRoot, signalToken string) {
+	server := internal.NewWebServer(
+		config,
+		internal.NewLiveStatsFetcher(procfsRoot, sysfsRoot),
+		newProcessManager(procfsRoot, sysfsRoot),
+		signalToken,
+	)
+	go server.Run(context.Background())
+
+	fmt.Printf("Serving the mintop web dashboard on http://%s\n", addr)
+	if err := http.ListenAndServe(addr, server); err != nil {
+		fmt.Println("Error running web dashboard:", err)
+		os.Exit(1)
+	}
+}